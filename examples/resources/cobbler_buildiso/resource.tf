@@ -0,0 +1,13 @@
+resource "cobbler_buildiso" "site" {
+  iso        = "/var/www/cobbler/pub/site-a.iso"
+  profiles   = [cobbler_profile.ubuntu.uid]
+  systems    = [cobbler_system.web.uid]
+  standalone = true
+  distro     = cobbler_distro.ubuntu.uid
+
+  # Rebuild the ISO when the profile's installation settings change.
+  triggers = {
+    autoinstall = cobbler_profile.ubuntu.autoinstall
+    kernel      = cobbler_distro.ubuntu.kernel
+  }
+}
@@ -0,0 +1,11 @@
+resource "cobbler_import" "ubuntu" {
+  name             = "Ubuntu-20.04"
+  path             = "/mnt/ubuntu-20.04"
+  breed            = "ubuntu"
+  autoinstall_file = "built-in-sample.seed"
+}
+
+resource "cobbler_system" "web" {
+  name    = "web01"
+  profile = cobbler_import.ubuntu.profile_uids[0]
+}
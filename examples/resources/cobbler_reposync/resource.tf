@@ -0,0 +1,20 @@
+resource "cobbler_repo" "updates" {
+  name           = "focal-updates"
+  breed          = "apt"
+  arch           = "x86_64"
+  apt_components = ["main"]
+  apt_dists      = ["focal-updates"]
+  mirror         = "http://us.archive.ubuntu.com/ubuntu/"
+  mirror_locally = true
+}
+
+resource "cobbler_reposync" "updates" {
+  repos   = [cobbler_repo.updates.name]
+  timeout = 7200
+
+  # Mirror again whenever the upstream URL or the distributions change.
+  triggers = {
+    mirror    = cobbler_repo.updates.mirror
+    apt_dists = join(",", cobbler_repo.updates.apt_dists)
+  }
+}
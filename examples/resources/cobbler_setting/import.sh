@@ -0,0 +1 @@
+terraform import cobbler_setting.manage_dhcp manage_dhcp
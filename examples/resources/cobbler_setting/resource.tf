@@ -0,0 +1,4 @@
+resource "cobbler_setting" "manage_dhcp" {
+  name  = "manage_dhcp"
+  value = "true"
+}
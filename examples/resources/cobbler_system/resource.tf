@@ -17,6 +17,12 @@ resource "cobbler_system" "my_system" {
   profile      = cobbler_profile.my_profile.uid
   name_servers = ["8.8.8.8", "8.8.4.4"]
   comment      = "I'm a system"
+
+  # Set a flag-only option such as quiet to null.
+  kernel_options = {
+    inherited = false
+    value     = { console = "ttyS0,115200", quiet = null }
+  }
 }
 
 resource "cobbler_network_interface" "eth0" {
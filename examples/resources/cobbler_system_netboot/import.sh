@@ -0,0 +1 @@
+terraform import cobbler_system_netboot.web 8f3a2c6e1b0d4e7f9a5c3b2d1e0f4a6b
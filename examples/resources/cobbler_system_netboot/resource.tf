@@ -0,0 +1,12 @@
+resource "cobbler_system" "web" {
+  name                   = "web01"
+  profile                = cobbler_profile.ubuntu.uid
+  ignore_netboot_enabled = true
+}
+
+# Reinstall web01 at its next network boot whenever the counter is raised. Cobbler
+# turns netboot off again after the installation without Terraform reporting drift.
+resource "cobbler_system_netboot" "web" {
+  system            = cobbler_system.web.uid
+  reinstall_trigger = "3"
+}
@@ -0,0 +1,22 @@
+resource "cobbler_system" "web" {
+  name            = "web01"
+  profile         = cobbler_profile.ubuntu.uid
+  netboot_enabled = true
+  power_type      = "ipmilan"
+  power_address   = "10.0.0.101"
+  power_user      = "admin"
+  power_pass      = var.ipmi_password
+}
+
+# Reboot into the installer once the system and its interfaces are in place, and again
+# whenever the system moves to another profile.
+resource "cobbler_system_power" "web" {
+  system = cobbler_system.web.uid
+  action = "reboot"
+
+  triggers = {
+    profile = cobbler_system.web.profile
+  }
+
+  depends_on = [cobbler_network_interface.web_eth0]
+}
@@ -62,3 +62,17 @@ func SkipIfCobblerVersionLessThan(t *testing.T, major, minor, patch int) {
 		t.Skipf("test requires Cobbler >= %s, server is %s", required, fmt.Sprintf("%d.%d.%d", tuple[0], tuple[1], tuple[2]))
 	}
 }
+
+// SkipUnlessDynamicSettings skips the test unless the Cobbler server accepts setting changes
+// through the API, which requires `allow_dynamic_settings: true` in its settings.yaml.
+func SkipUnlessDynamicSettings(t *testing.T) {
+	t.Helper()
+	result, err := CobblerApiClient.Call("get_settings", CobblerApiClient.Token)
+	if err != nil {
+		t.Skipf("could not read Cobbler settings: %v", err)
+	}
+	settings, _ := result.(map[string]interface{})
+	if enabled, _ := settings["allow_dynamic_settings"].(bool); !enabled {
+		t.Skip("test requires allow_dynamic_settings to be enabled on the Cobbler server")
+	}
+}
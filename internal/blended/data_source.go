@@ -0,0 +1,190 @@
+package blended
+
+import (
+	"context"
+	"fmt"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/cobbler/terraform-provider-cobbler/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BlendedDataSource{}
+
+type BlendedDataSource struct {
+	client cobbler.Client
+}
+
+func NewDataSource() datasource.DataSource {
+	return &BlendedDataSource{}
+}
+
+func (d *BlendedDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_blended"
+}
+
+func (d *BlendedDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to get the blended data of a Cobbler system, profile or image: the values it ends up with " +
+			"once everything it inherits from parent profiles, the distro and the settings is applied. Cobbler renders templates " +
+			"and boot files with this data.",
+		Attributes: map[string]schema.Attribute{
+			"system": schema.StringAttribute{
+				Description: "The name of the system. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("system"), path.MatchRoot("profile"), path.MatchRoot("image")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description: "The name of the profile. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+			},
+			"image": schema.StringAttribute{
+				Description: "The name of the image. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+			},
+			"arch": schema.StringAttribute{
+				Description: "The architecture.",
+				Computed:    true,
+			},
+			"autoinstall": schema.StringAttribute{
+				Description: "The autoinstall template.",
+				Computed:    true,
+			},
+			"autoinstall_meta": schema.DynamicAttribute{
+				Description: "The automatic installation template metadata.",
+				Computed:    true,
+			},
+			"boot_loaders": schema.ListAttribute{
+				Description: "The boot loaders.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"breed": schema.StringAttribute{
+				Description: "The operating system breed.",
+				Computed:    true,
+			},
+			"enable_ipxe": schema.BoolAttribute{
+				Description: "Whether iPXE is used instead of PXELINUX.",
+				Computed:    true,
+			},
+			"enable_menu": schema.BoolAttribute{
+				Description: "Whether the item is shown in the PXE boot menu.",
+				Computed:    true,
+			},
+			"kernel_options": schema.DynamicAttribute{
+				Description: "The kernel options.",
+				Computed:    true,
+			},
+			"kernel_options_post": schema.DynamicAttribute{
+				Description: "The post install kernel options.",
+				Computed:    true,
+			},
+			"name_servers": schema.ListAttribute{
+				Description: "The name servers.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"os_version": schema.StringAttribute{
+				Description: "The operating system version.",
+				Computed:    true,
+			},
+			"owners": schema.ListAttribute{
+				Description: "The owners for authz_ownership.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"virt_auto_boot": schema.BoolAttribute{
+				Description: "Whether virtual machines boot automatically.",
+				Computed:    true,
+			},
+			"virt_cpus": schema.Int64Attribute{
+				Description: "The number of virtual CPUs.",
+				Computed:    true,
+			},
+			"virt_file_size": schema.Float64Attribute{
+				Description: "The virtual machine file size.",
+				Computed:    true,
+			},
+			"virt_ram": schema.Int64Attribute{
+				Description: "The amount of RAM for the virtual machine.",
+				Computed:    true,
+			},
+			"raw": schema.DynamicAttribute{
+				Description: "An object with every key of the blended data, with the type Cobbler reports, e.g. " +
+					"`data.cobbler_blended.this.raw.http_server`. Attributes that are null in Cobbler are `\"~\"`.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *BlendedDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	d.client = cfg.CobblerClient
+}
+
+func (d *BlendedDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data blendedDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	what, name := "system", data.System.ValueString()
+	switch {
+	case !data.Profile.IsNull():
+		what, name = "profile", data.Profile.ValueString()
+	case !data.Image.IsNull():
+		what, name = "image", data.Image.ValueString()
+	}
+
+	blended, err := clientpkg.BlendedData(d.client, what, name)
+	if clientpkg.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(path.Root(what), "Unknown "+what, fmt.Sprintf("Cobbler has no %s named %q.", what, name))
+		return
+	}
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, fmt.Sprintf("Error reading blended data of Cobbler %s %q", what, name), err)
+		return
+	}
+
+	data.Arch = inherit.ResolvedString(blended["arch"])
+	data.Autoinstall = inherit.ResolvedString(blended["autoinstall"])
+	data.AutoinstallMeta = inherit.ResolvedMap(ctx, blended["autoinstall_meta"])
+	data.BootLoaders = inherit.ResolvedStringList(blended["boot_loaders"], &resp.Diagnostics)
+	data.Breed = inherit.ResolvedString(blended["breed"])
+	data.EnableIPXE = inherit.ResolvedBool(blended["enable_ipxe"])
+	data.EnableMenu = inherit.ResolvedBool(blended["enable_menu"])
+	data.KernelOptions = inherit.ResolvedMap(ctx, blended["kernel_options"])
+	data.KernelOptionsPost = inherit.ResolvedMap(ctx, blended["kernel_options_post"])
+	data.NameServers = inherit.ResolvedStringList(blended["name_servers"], &resp.Diagnostics)
+	data.OSVersion = inherit.ResolvedString(blended["os_version"])
+	data.Owners = inherit.ResolvedStringList(blended["owners"], &resp.Diagnostics)
+	data.VirtAutoBoot = inherit.ResolvedBool(blended["virt_auto_boot"])
+	data.VirtCPUs = inherit.ResolvedInt(blended["virt_cpus"])
+	data.VirtFileSize = inherit.ResolvedFloat64(blended["virt_file_size"])
+	data.VirtRAM = inherit.ResolvedInt(blended["virt_ram"])
+	data.Raw = types.DynamicValue(util.TerraformValue(ctx, blended))
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,26 @@
+package blended
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type blendedDataSourceModel struct {
+	System            types.String  `tfsdk:"system"`
+	Profile           types.String  `tfsdk:"profile"`
+	Image             types.String  `tfsdk:"image"`
+	Arch              types.String  `tfsdk:"arch"`
+	Autoinstall       types.String  `tfsdk:"autoinstall"`
+	AutoinstallMeta   types.Dynamic `tfsdk:"autoinstall_meta"`
+	BootLoaders       types.List    `tfsdk:"boot_loaders"`
+	Breed             types.String  `tfsdk:"breed"`
+	EnableIPXE        types.Bool    `tfsdk:"enable_ipxe"`
+	EnableMenu        types.Bool    `tfsdk:"enable_menu"`
+	KernelOptions     types.Dynamic `tfsdk:"kernel_options"`
+	KernelOptionsPost types.Dynamic `tfsdk:"kernel_options_post"`
+	NameServers       types.List    `tfsdk:"name_servers"`
+	OSVersion         types.String  `tfsdk:"os_version"`
+	Owners            types.List    `tfsdk:"owners"`
+	VirtAutoBoot      types.Bool    `tfsdk:"virt_auto_boot"`
+	VirtCPUs          types.Int64   `tfsdk:"virt_cpus"`
+	VirtFileSize      types.Float64 `tfsdk:"virt_file_size"`
+	VirtRAM           types.Int64   `tfsdk:"virt_ram"`
+	Raw               types.Dynamic `tfsdk:"raw"`
+}
@@ -0,0 +1,74 @@
+package blended_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBlendedDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBlendedDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					// Inherited from the profile and the distro.
+					resource.TestCheckResourceAttr("data.cobbler_blended.system", "virt_ram", "2048"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.system", "kernel_options.console", "ttyS0"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.system", "kernel_options.nomodeset", "1"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.system", "arch", "x86_64"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.system", "raw.name", "foo-data-source-blended"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.profile", "virt_ram", "2048"),
+					resource.TestCheckResourceAttr("data.cobbler_blended.profile", "breed", "ubuntu"),
+				),
+			},
+		},
+	})
+}
+
+const testAccBlendedDataSourceBasic = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-data-source-blended"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+
+  kernel_options = {
+    inherited = false
+    value     = { console = "ttyS0" }
+  }
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-data-source-blended"
+  distro = cobbler_distro.foo.uid
+
+  virt_ram = {
+    inherited = false
+    value     = 2048
+  }
+}
+
+resource "cobbler_system" "foo" {
+  name    = "foo-data-source-blended"
+  profile = cobbler_profile.foo.uid
+
+  kernel_options = {
+    inherited = false
+    value     = { nomodeset = "1" }
+  }
+}
+
+data "cobbler_blended" "system" {
+  system = cobbler_system.foo.name
+}
+
+data "cobbler_blended" "profile" {
+  profile = cobbler_profile.foo.name
+}
+`
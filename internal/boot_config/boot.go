@@ -0,0 +1,100 @@
+package boot_config
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// systemPXEFile returns the PXELINUX configuration Cobbler's sync writes for a system's MAC
+// address.
+func systemPXEFile(mac string) string {
+	return "pxelinux.cfg/01-" + strings.ReplaceAll(strings.ToLower(mac), ":", "-")
+}
+
+// systemGRUBFile returns the GRUB configuration Cobbler's sync writes for a system's MAC
+// address.
+func systemGRUBFile(mac string) string {
+	return path.Join("grub/system", strings.ToLower(mac))
+}
+
+// grubMenuFile returns the GRUB menu Cobbler's sync writes for the profiles and images of an
+// architecture.
+func grubMenuFile(arch string) string {
+	return path.Join("grub", arch+"_menu_items.cfg")
+}
+
+// pxeMenuFile is the PXELINUX menu Cobbler's sync writes for all profiles and images.
+const pxeMenuFile = "pxelinux.cfg/default"
+
+// pxeMenuEntry returns the LABEL block of name in a PXELINUX menu, or "" if there is none.
+func pxeMenuEntry(menu, name string) string {
+	var entry []string
+	for _, line := range strings.SplitAfter(menu, "\n") {
+		fields := strings.Fields(line)
+		isLabel := len(fields) > 0 && strings.EqualFold(fields[0], "LABEL")
+		if entry != nil {
+			if isLabel || (len(fields) > 1 && strings.EqualFold(fields[0], "MENU") && strings.EqualFold(fields[1], "END")) {
+				break
+			}
+			entry = append(entry, line)
+			continue
+		}
+		if isLabel && len(fields) == 2 && fields[1] == name {
+			entry = []string{line}
+		}
+	}
+	return strings.Join(entry, "")
+}
+
+// grubMenuEntry returns the menuentry block titled name in a GRUB menu, or "" if there is
+// none. Cobbler writes each entry's closing brace on a line of its own.
+func grubMenuEntry(menu, name string) string {
+	var entry []string
+	for _, line := range strings.SplitAfter(menu, "\n") {
+		if entry == nil {
+			if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "menuentry '"+name+"'") ||
+				strings.HasPrefix(trimmed, `menuentry "`+name+`"`) {
+				entry = []string{line}
+			}
+			continue
+		}
+		entry = append(entry, line)
+		if strings.TrimSpace(line) == "}" {
+			break
+		}
+	}
+	return strings.Join(entry, "")
+}
+
+// firstMAC returns the MAC address of the first of a system's blended interfaces, in name
+// order, that has one.
+func firstMAC(interfaces interface{}) string {
+	ifaces, _ := interfaces.(map[string]interface{})
+	names := make([]string, 0, len(ifaces))
+	for name := range ifaces {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		iface, _ := ifaces[name].(map[string]interface{})
+		if mac, _ := iface["mac_address"].(string); mac != "" && mac != "~" {
+			return mac
+		}
+	}
+	return ""
+}
+
+// grubBinaries returns the file of every GRUB binary Cobbler builds, keyed by boot loader
+// format, from the bootloaders_formats setting.
+func grubBinaries(formats interface{}) map[string]string {
+	entries, _ := formats.(map[string]interface{})
+	files := make(map[string]string, len(entries))
+	for format, entry := range entries {
+		e, _ := entry.(map[string]interface{})
+		if binary, _ := e["binary_name"].(string); binary != "" {
+			files[format] = path.Join("grub", binary)
+		}
+	}
+	return files
+}
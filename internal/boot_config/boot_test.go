@@ -0,0 +1,72 @@
+package boot_config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSystemFiles(t *testing.T) {
+	if got := systemPXEFile("AA:BB:CC:DD:EE:FF"); got != "pxelinux.cfg/01-aa-bb-cc-dd-ee-ff" {
+		t.Errorf("unexpected PXELINUX file %q", got)
+	}
+	if got := systemGRUBFile("AA:BB:CC:DD:EE:FF"); got != "grub/system/aa:bb:cc:dd:ee:ff" {
+		t.Errorf("unexpected GRUB file %q", got)
+	}
+}
+
+func TestPXEMenuEntry(t *testing.T) {
+	menu := "DEFAULT menu\nMENU TITLE Cobbler\n\n" +
+		"LABEL local\n\tMENU LABEL (local)\n\tLOCALBOOT -1\n\n" +
+		"LABEL foo\n\tMENU LABEL foo\n\tkernel /images/foo/vmlinuz\n\tappend initrd=/images/foo/initrd.gz quiet\n\n" +
+		"LABEL foo-bar\n\tkernel /images/foo-bar/vmlinuz\n" +
+		"MENU end\n"
+	want := "LABEL foo\n\tMENU LABEL foo\n\tkernel /images/foo/vmlinuz\n\tappend initrd=/images/foo/initrd.gz quiet\n\n"
+	if got := pxeMenuEntry(menu, "foo"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := pxeMenuEntry(menu, "foo-bar"); got != "LABEL foo-bar\n\tkernel /images/foo-bar/vmlinuz\n" {
+		t.Errorf("expected the entry to end at MENU END, got %q", got)
+	}
+	if got := pxeMenuEntry(menu, "missing"); got != "" {
+		t.Errorf("expected no entry, got %q", got)
+	}
+}
+
+func TestGRUBMenuEntry(t *testing.T) {
+	menu := "menuentry 'foo-bar' --class gnu-linux {\n  linux /images/foo-bar/vmlinuz\n}\n" +
+		"menuentry 'foo' --class gnu-linux --class gnu --class os {\n  echo 'Loading kernel ...'\n  linux /images/foo/vmlinuz quiet\n}\n"
+	want := "menuentry 'foo' --class gnu-linux --class gnu --class os {\n  echo 'Loading kernel ...'\n  linux /images/foo/vmlinuz quiet\n}\n"
+	if got := grubMenuEntry(menu, "foo"); got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+	if got := grubMenuEntry(menu, "missing"); got != "" {
+		t.Errorf("expected no entry, got %q", got)
+	}
+}
+
+func TestFirstMAC(t *testing.T) {
+	interfaces := map[string]interface{}{
+		"eth1": map[string]interface{}{"mac_address": "aa:bb:cc:dd:ee:01"},
+		"eth0": map[string]interface{}{"mac_address": ""},
+		"bmc":  map[string]interface{}{"mac_address": "~"},
+		"eth2": map[string]interface{}{"mac_address": "aa:bb:cc:dd:ee:02"},
+	}
+	if got := firstMAC(interfaces); got != "aa:bb:cc:dd:ee:01" {
+		t.Errorf("unexpected MAC address %q", got)
+	}
+	if got := firstMAC(nil); got != "" {
+		t.Errorf("expected no MAC address, got %q", got)
+	}
+}
+
+func TestGRUBBinaries(t *testing.T) {
+	formats := map[string]interface{}{
+		"x86_64-efi":   map[string]interface{}{"binary_name": "grubx64.efi", "extra_modules": []interface{}{"chain"}},
+		"i386-pc-pxe":  map[string]interface{}{"binary_name": "grub.0"},
+		"without-name": map[string]interface{}{"mod_dir": "foo"},
+	}
+	want := map[string]string{"x86_64-efi": "grub/grubx64.efi", "i386-pc-pxe": "grub/grub.0"}
+	if got := grubBinaries(formats); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,244 @@
+package boot_config
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &BootConfigDataSource{}
+
+type BootConfigDataSource struct {
+	client cobbler.Client
+}
+
+func NewDataSource() datasource.DataSource {
+	return &BootConfigDataSource{}
+}
+
+func (d *BootConfigDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_boot_config"
+}
+
+func (d *BootConfigDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to see how a Cobbler system, profile or image boots from the network: its boot loaders, " +
+			"the GRUB binaries Cobbler builds, the iPXE script Cobbler generates for it and, read over TFTP, the PXELINUX and GRUB " +
+			"configuration Cobbler's last sync wrote for it.",
+		Attributes: map[string]schema.Attribute{
+			"system": schema.StringAttribute{
+				Description: "The name of the system. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("system"), path.MatchRoot("profile"), path.MatchRoot("image")),
+				},
+			},
+			"profile": schema.StringAttribute{
+				Description: "The name of the profile. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+			},
+			"image": schema.StringAttribute{
+				Description: "The name of the image. Exactly one of `system`, `profile` and `image` must be set.",
+				Optional:    true,
+			},
+			"tftp_server": schema.StringAttribute{
+				Description: "The TFTP server to read `pxe` and `grub` from, with an optional port, usually the item's `next_server_v4`. " +
+					"Without it, `pxe` and `grub` are null.",
+				Optional: true,
+			},
+			"boot_loaders": schema.ListAttribute{
+				Description: "The boot loaders the item can be booted with, including inherited ones.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"boot_loader_files": schema.MapAttribute{
+				Description: "The TFTP path of the GRUB binary Cobbler builds for each boot loader format, from its `bootloaders_formats` " +
+					"setting, e.g. `grub/grubx64.efi` for `x86_64-efi`. Empty unless `boot_loaders` contains `grub`.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"pxe": schema.StringAttribute{
+				Description: "The PXELINUX configuration: a system's own file, or a profile's or image's entry in the PXELINUX menu. " +
+					"Null unless `tftp_server` is set and `boot_loaders` contains `pxe`, for systems without a MAC address, and for " +
+					"profiles and images that are not in the menu.",
+				Computed: true,
+			},
+			"grub": schema.StringAttribute{
+				Description: "The GRUB configuration: a system's own file, or a profile's or image's entry in the GRUB menu of its " +
+					"architecture. Null in the same cases as `pxe`, with `grub` instead of `pxe` in `boot_loaders`.",
+				Computed: true,
+			},
+			"ipxe": schema.StringAttribute{
+				Description: "The iPXE script Cobbler generates. Null unless `boot_loaders` contains `ipxe`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *BootConfigDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	d.client = cfg.CobblerClient
+}
+
+func (d *BootConfigDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data bootConfigDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	what, name := "system", data.System.ValueString()
+	switch {
+	case !data.Profile.IsNull():
+		what, name = "profile", data.Profile.ValueString()
+	case !data.Image.IsNull():
+		what, name = "image", data.Image.ValueString()
+	}
+
+	data.PXE = types.StringNull()
+	data.GRUB = types.StringNull()
+	data.IPXE = types.StringNull()
+
+	var bootLoaders []string
+	var arch, mac string
+	if what == "image" {
+		image, err := d.client.GetImage(name, false, true)
+		if err != nil {
+			d.addItemError(&resp.Diagnostics, what, name, err)
+			return
+		}
+		bootLoaders, arch = image.BootLoaders, image.Arch
+	} else {
+		var err error
+		if what == "system" {
+			_, err = d.client.GetSystem(name, false, false)
+		} else {
+			_, err = d.client.GetProfile(name, false, false)
+		}
+		if err != nil {
+			d.addItemError(&resp.Diagnostics, what, name, err)
+			return
+		}
+		blended, err := clientpkg.BlendedData(d.client, what, name)
+		if err != nil {
+			clientpkg.AddClientError(&resp.Diagnostics, fmt.Sprintf("Error reading blended data of Cobbler %s %q", what, name), err)
+			return
+		}
+		bootLoaders = stringList(blended["boot_loaders"])
+		arch, _ = blended["arch"].(string)
+		mac = firstMAC(blended["interfaces"])
+	}
+
+	files := map[string]string{}
+	if slices.Contains(bootLoaders, "grub") {
+		result, err := d.client.Call("get_settings", d.client.Token)
+		if err != nil {
+			clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler settings", err)
+			return
+		}
+		settings, _ := result.(map[string]interface{})
+		files = grubBinaries(settings["bootloaders_formats"])
+	}
+
+	// Cobbler writes no boot loader configuration for systems without a MAC address.
+	if server := data.TFTPServer.ValueString(); server != "" && (what != "system" || mac != "") {
+		pxeFile, grubFile := pxeMenuFile, grubMenuFile(arch)
+		if what == "system" {
+			pxeFile, grubFile = systemPXEFile(mac), systemGRUBFile(mac)
+		}
+		if slices.Contains(bootLoaders, "pxe") {
+			data.PXE = d.readBootConfig(ctx, server, pxeFile, what, name, pxeMenuEntry, &resp.Diagnostics)
+		}
+		if slices.Contains(bootLoaders, "grub") {
+			data.GRUB = d.readBootConfig(ctx, server, grubFile, what, name, grubMenuEntry, &resp.Diagnostics)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if slices.Contains(bootLoaders, "ipxe") {
+		args := map[string]string{what: name}
+		result, err := d.client.Call("generate_ipxe", args["profile"], args["image"], args["system"])
+		if err != nil {
+			clientpkg.AddClientError(&resp.Diagnostics, fmt.Sprintf("Error generating iPXE script of Cobbler %s %q", what, name), err)
+			return
+		}
+		script, _ := result.(string)
+		data.IPXE = types.StringValue(script)
+	}
+
+	var diags diag.Diagnostics
+	data.BootLoaders, diags = types.ListValueFrom(ctx, types.StringType, bootLoaders)
+	resp.Diagnostics.Append(diags...)
+	data.BootLoaderFiles, diags = types.MapValueFrom(ctx, types.StringType, files)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// addItemError reports a failure to read the item, against its attribute if it does not exist.
+func (d *BootConfigDataSource) addItemError(diags *diag.Diagnostics, what, name string, err error) {
+	if clientpkg.IsNotFound(err) {
+		diags.AddAttributeError(path.Root(what), "Unknown "+what, fmt.Sprintf("Cobbler has no %s named %q.", what, name))
+		return
+	}
+	clientpkg.AddClientError(diags, fmt.Sprintf("Error reading Cobbler %s %q", what, name), err)
+}
+
+// readBootConfig reads file, which Cobbler's sync wrote, from the TFTP server. A system's file
+// is its configuration; for profiles and images the file is a menu, and entry picks the
+// item's part of it. An item missing from the menu yields null.
+func (d *BootConfigDataSource) readBootConfig(ctx context.Context, server, file, what, name string,
+	entry func(menu, name string) string, diags *diag.Diagnostics) types.String {
+	content, err := clientpkg.ReadTFTPFile(ctx, server, file)
+	if clientpkg.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("tftp_server"), "Boot configuration not found",
+			fmt.Sprintf("The TFTP server %s has no %s. Cobbler writes it when it syncs, which sync_mode = \"none\" leaves to you.", server, file))
+		return types.StringNull()
+	}
+	if err != nil {
+		diags.AddAttributeError(path.Root("tftp_server"), "Error reading boot configuration over TFTP", err.Error())
+		return types.StringNull()
+	}
+	if what == "system" {
+		return types.StringValue(string(content))
+	}
+	if config := entry(string(content), name); config != "" {
+		return types.StringValue(config)
+	}
+	return types.StringNull()
+}
+
+func stringList(v interface{}) []string {
+	items, _ := v.([]interface{})
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			list = append(list, s)
+		}
+	}
+	return list
+}
@@ -0,0 +1,15 @@
+package boot_config
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type bootConfigDataSourceModel struct {
+	System          types.String `tfsdk:"system"`
+	Profile         types.String `tfsdk:"profile"`
+	Image           types.String `tfsdk:"image"`
+	TFTPServer      types.String `tfsdk:"tftp_server"`
+	BootLoaders     types.List   `tfsdk:"boot_loaders"`
+	BootLoaderFiles types.Map    `tfsdk:"boot_loader_files"`
+	PXE             types.String `tfsdk:"pxe"`
+	GRUB            types.String `tfsdk:"grub"`
+	IPXE            types.String `tfsdk:"ipxe"`
+}
@@ -0,0 +1,67 @@
+package boot_config_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBootConfigDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBootConfigDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.cobbler_boot_config.system", "boot_loaders.0", "grub"),
+					resource.TestMatchResourceAttr("data.cobbler_boot_config.system", "boot_loader_files.x86_64-efi", regexp.MustCompile(`^grub/.+\.efi$`)),
+					resource.TestCheckNoResourceAttr("data.cobbler_boot_config.system", "grub"),
+					resource.TestCheckNoResourceAttr("data.cobbler_boot_config.system", "pxe"),
+					resource.TestCheckNoResourceAttr("data.cobbler_boot_config.system", "ipxe"),
+					resource.TestCheckResourceAttrSet("data.cobbler_boot_config.profile", "boot_loaders.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccBootConfigDataSourceBasic = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-data-source-boot-config"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-data-source-boot-config"
+  distro = cobbler_distro.foo.uid
+}
+
+resource "cobbler_system" "foo" {
+  name    = "foo-data-source-boot-config"
+  profile = cobbler_profile.foo.uid
+
+  boot_loaders = {
+    inherited = false
+    value     = ["grub"]
+  }
+  kernel_options = {
+    inherited = false
+    value     = { console = "ttyS0", quiet = null }
+  }
+}
+
+data "cobbler_boot_config" "system" {
+  system = cobbler_system.foo.name
+}
+
+data "cobbler_boot_config" "profile" {
+  profile = cobbler_profile.foo.name
+}
+`
@@ -0,0 +1,280 @@
+package buildiso
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &BuildisoResource{}
+var _ resource.ResourceWithValidateConfig = &BuildisoResource{}
+
+type BuildisoResource struct {
+	client cobbler.Client
+	tasks  *clientpkg.TaskRunner
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &BuildisoResource{}
+}
+
+func (r *BuildisoResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_buildiso"
+}
+
+func (r *BuildisoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_buildiso` builds a bootable ISO on the Cobbler server, like `cobbler buildiso`. " +
+			"The build runs as a Cobbler background task when the resource is created and again whenever an argument other than `timeout` changes. " +
+			"Destroying the resource leaves the ISO in place.",
+		Attributes: map[string]schema.Attribute{
+			"iso": schema.StringAttribute{
+				Description: "The path on the Cobbler server the ISO is written to. Changing this builds a new ISO.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"profiles": schema.ListAttribute{
+				Description: "The UIDs of the profiles to include in the boot menu, e.g. `[cobbler_profile.foo.uid]`. " +
+					"If neither `profiles` nor `systems` is set, Cobbler includes every profile.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"systems": schema.ListAttribute{
+				Description: "The UIDs of the systems to include in the boot menu, with their network configuration, e.g. `[cobbler_system.foo.uid]`.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"standalone": schema.BoolAttribute{
+				Description: "Build a standalone ISO that installs from the distro tree on the ISO instead of from the Cobbler server. Requires `distro`. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"airgapped": schema.BoolAttribute{
+				Description: "Build a standalone ISO that also carries the repos of the profiles, for sites without any network access to Cobbler. Requires `distro`. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"distro": schema.StringAttribute{
+				Description: "The UID of the distro a standalone or airgapped ISO is built from, e.g. `cobbler_distro.foo.uid`.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source": schema.StringAttribute{
+				Description: "The path of the distro tree copied onto a standalone or airgapped ISO. Defaults to the tree Cobbler imported the distro from.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"exclude_dns": schema.BoolAttribute{
+				Description: "Leave the systems' DNS settings out of the kernel command line. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that rebuild the ISO when they change, e.g. the autoinstall templates of the profiles.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The number of seconds to wait for the buildiso task to finish. Defaults to `1800`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(int64(clientpkg.DefaultTaskTimeout / time.Second)),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"task_id": schema.StringAttribute{
+				Description: "The event id of the Cobbler buildiso task.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// ValidateConfig rejects standalone and airgapped builds without a distro at plan time.
+func (r *BuildisoResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data buildisoResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (data.Standalone.ValueBool() || data.Airgapped.ValueBool()) && !data.Distro.IsUnknown() && data.Distro.ValueString() == "" {
+		resp.Diagnostics.AddAttributeError(path.Root("distro"), "Missing distro",
+			"A standalone or airgapped ISO is built from a distro; set distro to its UID.")
+	}
+}
+
+func (r *BuildisoResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.client = cfg.CobblerClient
+	r.tasks = cfg.Tasks
+	r.writes = cfg.Writes
+}
+
+func (r *BuildisoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data buildisoResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var profileUIDs, systemUIDs []string
+	resp.Diagnostics.Append(data.Profiles.ElementsAs(ctx, &profileUIDs, false)...)
+	resp.Diagnostics.Append(data.Systems.ElementsAs(ctx, &systemUIDs, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Cobbler's buildiso takes item names, while the other resources reference items by UID.
+	profiles := r.names(ctx, "profile", profileUIDs, path.Root("profiles"), &resp.Diagnostics)
+	systems := r.names(ctx, "system", systemUIDs, path.Root("systems"), &resp.Diagnostics)
+	var distro string
+	if uid := data.Distro.ValueString(); uid != "" {
+		if names := r.names(ctx, "distro", []string{uid}, path.Root("distro"), &resp.Diagnostics); len(names) == 1 {
+			distro = names[0]
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	options := map[string]interface{}{
+		"iso":         data.ISO.ValueString(),
+		"profiles":    profiles,
+		"systems":     systems,
+		"standalone":  data.Standalone.ValueBool(),
+		"airgapped":   data.Airgapped.ValueBool(),
+		"distro":      distro,
+		"source":      data.Source.ValueString(),
+		"exclude_dns": data.ExcludeDNS.ValueBool(),
+	}
+
+	tflog.Debug(ctx, "Cobbler Buildiso: Create", map[string]interface{}{"iso": data.ISO.ValueString(), "profiles": profiles, "systems": systems})
+
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
+	taskID, err := r.tasks.Run(ctx, "background_buildiso", options, timeout)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error building Cobbler ISO", err)
+		return
+	}
+
+	data.TaskID = types.StringValue(taskID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BuildisoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// The ISO is a file on the Cobbler server that the API cannot inspect.
+	var data buildisoResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BuildisoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Only timeout can change in place; it applies to the next build.
+	var data buildisoResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BuildisoResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Cobbler has no API to remove a built ISO.
+}
+
+// names returns the names of the Cobbler items of type what with the given UIDs. Unknown
+// UIDs are reported against attr.
+func (r *BuildisoResource) names(ctx context.Context, what string, uids []string, attr path.Path, diags *diag.Diagnostics) []string {
+	names := make([]string, 0, len(uids))
+	for _, uid := range uids {
+		name, err := clientpkg.ItemName(r.client, what, uid)
+		if clientpkg.IsNotFound(err) {
+			diags.AddAttributeError(attr, "Unknown UID", fmt.Sprintf("Cobbler has no %s with UID %q.", what, uid))
+			continue
+		}
+		if err != nil {
+			clientpkg.AddClientError(diags, fmt.Sprintf("Error finding Cobbler %s %s", what, uid), err)
+			return nil
+		}
+		tflog.Trace(ctx, "Resolved Cobbler UID", map[string]interface{}{"type": what, "uid": uid, "name": name})
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,17 @@
+package buildiso
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type buildisoResourceModel struct {
+	ISO        types.String `tfsdk:"iso"`
+	Profiles   types.List   `tfsdk:"profiles"`
+	Systems    types.List   `tfsdk:"systems"`
+	Standalone types.Bool   `tfsdk:"standalone"`
+	Airgapped  types.Bool   `tfsdk:"airgapped"`
+	Distro     types.String `tfsdk:"distro"`
+	Source     types.String `tfsdk:"source"`
+	ExcludeDNS types.Bool   `tfsdk:"exclude_dns"`
+	Triggers   types.Map    `tfsdk:"triggers"`
+	Timeout    types.Int64  `tfsdk:"timeout"`
+	TaskID     types.String `tfsdk:"task_id"`
+}
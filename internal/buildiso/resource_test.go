@@ -0,0 +1,70 @@
+package buildiso_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccBuildisoResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBuildisoResourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_buildiso.foo", "iso", "/tmp/foo-resource-buildiso-basic.iso"),
+					resource.TestCheckResourceAttr("cobbler_buildiso.foo", "standalone", "false"),
+					resource.TestCheckResourceAttrSet("cobbler_buildiso.foo", "task_id"),
+				),
+			},
+		},
+	})
+}
+
+// TestAccBuildisoResource_standaloneWithoutDistro checks that a standalone build without a
+// distro is rejected at plan time.
+func TestAccBuildisoResource_standaloneWithoutDistro(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccBuildisoResourceStandaloneWithoutDistro,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing distro`),
+			},
+		},
+	})
+}
+
+const testAccBuildisoResourceBasic = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-buildiso-basic"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-buildiso-basic"
+  distro = cobbler_distro.foo.uid
+}
+
+resource "cobbler_buildiso" "foo" {
+  iso      = "/tmp/foo-resource-buildiso-basic.iso"
+  profiles = [cobbler_profile.foo.uid]
+}
+`
+
+const testAccBuildisoResourceStandaloneWithoutDistro = `
+resource "cobbler_buildiso" "foo" {
+  iso        = "/tmp/foo-resource-buildiso-standalone.iso"
+  standalone = true
+}
+`
@@ -1,11 +1,11 @@
 package client
 
 import (
-	"crypto/tls"
-	"crypto/x509"
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	cobbler "github.com/cobbler/cobblerclient"
 )
@@ -17,11 +17,49 @@ type Config struct {
 	URL        string
 	Username   string
 	Password   string
+	SyncMode   string
+	// ForceFullSync upgrades per-system and DHCP-only syncs to full syncs.
+	ForceFullSync bool
+	// CACertAppend adds CACertFile to the system CA pool instead of replacing it.
+	CACertAppend bool
+	// ClientCertFile and ClientKeyFile hold the path or PEM content of a client certificate.
+	ClientCertFile string
+	ClientKeyFile  string
+	// TLSServerName overrides the host name the server certificate is verified against.
+	TLSServerName string
+	// TLSMinVersion is one of the TLSVersions keys, or "" for the crypto/tls default.
+	TLSMinVersion string
+	// Headers are added to every request, e.g. for a gateway in front of Cobbler.
+	Headers map[string]string
+	// APIPath replaces the path of URL for XML-RPC requests if set.
+	APIPath string
+	// RequestTimeout limits every single request attempt; zero means no limit.
+	RequestTimeout time.Duration
+	// MaxRetries is how often a request is retried after a transient failure.
+	MaxRetries int
+	// RetryMaxWait caps the exponential backoff between two retries.
+	RetryMaxWait time.Duration
+	// ReadOnly rejects every call that would modify Cobbler, including syncs, before it is
+	// sent. Reads keep working.
+	ReadOnly bool
+	// Unknown lists provider attributes whose values are not known during this plan. API
+	// calls fail with an explanatory error until Terraform configures the provider again.
+	Unknown []string
 
 	CobblerClient cobbler.Client
+	// Syncer is shared by every resource so that syncs can be coalesced provider-wide.
+	Syncer *Syncer
+	// Tasks runs Cobbler background tasks such as imports and reposyncs.
+	Tasks *TaskRunner
+	// Writes is checked by every resource before it creates, updates or deletes anything.
+	Writes *WriteGuard
+	// Changes is shared by every resource so that items plan their effective values as
+	// unknown when one of their parents changes.
+	Changes *PlannedChanges
 }
 
-// LoadAndValidate configures the Cobbler client, performs TLS setup, and logs in.
+// LoadAndValidate configures the Cobbler client and performs TLS setup. Logging in is
+// deferred until the first API call that needs a token.
 // The readFile parameter is a function that reads a file path or returns the string as-is.
 func (c *Config) LoadAndValidate(readFile func(string) (string, bool, error)) error {
 	config := cobbler.ClientConfig{
@@ -30,20 +68,9 @@ func (c *Config) LoadAndValidate(readFile func(string) (string, bool, error)) er
 		Password: c.Password,
 	}
 
-	tlsConfig := &tls.Config{}
-	if c.CACertFile != "" {
-		caCert, _, err := readFile(c.CACertFile)
-		if err != nil {
-			return fmt.Errorf("error reading CA Cert: %s", err)
-		}
-
-		caCertPool := x509.NewCertPool()
-		caCertPool.AppendCertsFromPEM([]byte(caCert))
-		tlsConfig.RootCAs = caCertPool
-	}
-
-	if c.Insecure {
-		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	tlsConfig, err := c.buildTLSConfig(readFile)
+	if err != nil {
+		return err
 	}
 
 	transport := &http.Transport{
@@ -51,18 +78,37 @@ func (c *Config) LoadAndValidate(readFile func(string) (string, bool, error)) er
 		TLSClientConfig: tlsConfig,
 	}
 
-	httpClient := &http.Client{Transport: transport}
+	session := newSessionTransport(newRequestTransport(transport, c.Headers, c.APIPath, c.RequestTimeout), c.MaxRetries, c.RetryMaxWait)
+	var roundTripper http.RoundTripper = session
+	if c.ReadOnly {
+		roundTripper = &readOnlyTransport{base: session}
+	}
+	httpClient := &http.Client{Transport: roundTripper}
 
 	client := cobbler.NewClient(httpClient, config)
-	_, err := client.Login()
-	if err != nil {
-		var vErr *cobbler.UnsupportedServerVersionError
-		if errors.As(err, &vErr) {
-			return fmt.Errorf("cobbler server too old: %w (terraform-provider-cobbler v6 requires Cobbler 4.0.0+)", err)
+	// Log in on the first API call rather than here, so that validating and planning work
+	// while Cobbler is unreachable or the credentials are not known yet.
+	client.Token = pendingToken
+	session.start(pendingToken, func() (string, error) {
+		if len(c.Unknown) > 0 {
+			return "", fmt.Errorf("the provider configuration for %s is not known until apply", strings.Join(c.Unknown, ", "))
 		}
-		return fmt.Errorf("failed to login: %s", err)
-	}
+		loginClient := client
+		if _, err := loginClient.Login(); err != nil {
+			var vErr *cobbler.UnsupportedServerVersionError
+			if errors.As(err, &vErr) {
+				return "", fmt.Errorf("cobbler server too old: %w (terraform-provider-cobbler v6 requires Cobbler 4.0.0+)", err)
+			}
+			return "", err
+		}
+		return loginClient.Token, nil
+	})
 
 	c.CobblerClient = client
+	c.Syncer = NewSyncer(client, c.SyncMode, c.ForceFullSync)
+	c.Syncer.readOnly = c.ReadOnly
+	c.Writes = &WriteGuard{readOnly: c.ReadOnly}
+	c.Changes = &PlannedChanges{}
+	c.Tasks = NewTaskRunner(client)
 	return nil
 }
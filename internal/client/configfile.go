@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultConfigFile is where the Go cobbler CLI keeps its configuration. The provider reads
+// it when it exists and no other config file was requested.
+const DefaultConfigFile = "~/.cobbler.yaml"
+
+// FileProfile holds the connection settings of one server in a config file. The keys match
+// the ones the Go cobbler CLI reads from ~/.cobbler.yaml.
+type FileProfile struct {
+	URL             string `yaml:"server_url"`
+	Username        string `yaml:"server_username"`
+	Password        string `yaml:"server_password"`
+	PasswordFile    string `yaml:"password_file"`
+	PasswordCommand string `yaml:"password_command"`
+	Insecure        *bool  `yaml:"insecure"`
+	CACertFile      string `yaml:"cacert_file"`
+}
+
+// configFile is the layout of a config file: the top-level settings are the default server,
+// and every entry of profiles overrides them for a named server.
+type configFile struct {
+	FileProfile `yaml:",inline"`
+	Profiles    map[string]FileProfile `yaml:"profiles"`
+}
+
+// LoadConfigFile reads the named profile from the YAML config file at path. An empty path
+// reads DefaultConfigFile if it exists and returns an empty profile otherwise. An empty
+// profile name selects the top-level settings.
+func LoadConfigFile(path, profile string) (FileProfile, error) {
+	explicit := path != ""
+	if !explicit {
+		path = DefaultConfigFile
+	}
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return FileProfile{}, fmt.Errorf("error expanding config file path %q: %s", path, err)
+	}
+
+	content, err := os.ReadFile(expanded)
+	if errors.Is(err, os.ErrNotExist) && !explicit {
+		if profile != "" {
+			return FileProfile{}, fmt.Errorf("profile %q requested, but no config file was found at %s", profile, path)
+		}
+		return FileProfile{}, nil
+	}
+	if err != nil {
+		return FileProfile{}, fmt.Errorf("error reading config file: %s", err)
+	}
+
+	var file configFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return FileProfile{}, fmt.Errorf("error parsing config file %s: %s", path, err)
+	}
+	if profile == "" {
+		return file.FileProfile, nil
+	}
+
+	named, ok := file.Profiles[profile]
+	if !ok {
+		names := make([]string, 0, len(file.Profiles))
+		for name := range file.Profiles {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		return FileProfile{}, fmt.Errorf("profile %q not found in config file %s (available: %s)", profile, path, strings.Join(names, ", "))
+	}
+	return named.withDefaults(file.FileProfile), nil
+}
+
+// withDefaults fills every setting p leaves empty from defaults.
+func (p FileProfile) withDefaults(defaults FileProfile) FileProfile {
+	fill := func(v *string, d string) {
+		if *v == "" {
+			*v = d
+		}
+	}
+	fill(&p.URL, defaults.URL)
+	fill(&p.Username, defaults.Username)
+	fill(&p.CACertFile, defaults.CACertFile)
+	if p.Insecure == nil {
+		p.Insecure = defaults.Insecure
+	}
+	// A profile that configures any password source must not inherit a different one.
+	if p.Password == "" && p.PasswordFile == "" && p.PasswordCommand == "" {
+		p.Password = defaults.Password
+		p.PasswordFile = defaults.PasswordFile
+		p.PasswordCommand = defaults.PasswordCommand
+	}
+	return p
+}
+
+// ReadPasswordFile returns the password stored in the file at path, without the trailing
+// newline editors and `echo` add.
+func ReadPasswordFile(path string) (string, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return "", fmt.Errorf("error expanding password file path %q: %s", path, err)
+	}
+	content, err := os.ReadFile(expanded)
+	if err != nil {
+		return "", fmt.Errorf("error reading password file: %s", err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// RunPasswordCommand runs command through the system shell and returns its standard output
+// without the trailing newline, e.g. for `pass show cobbler` or a secrets manager CLI.
+func RunPasswordCommand(ctx context.Context, command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("password command failed: %s: %s", err, msg)
+		}
+		return "", fmt.Errorf("password command failed: %s", err)
+	}
+	return strings.TrimRight(stdout.String(), "\r\n"), nil
+}
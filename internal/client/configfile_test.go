@@ -0,0 +1,136 @@
+package client_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/client"
+)
+
+const testConfigFile = `
+server_url: http://cobbler.example.com/cobbler_api
+server_username: cobbler
+server_password: default-secret
+insecure: true
+profiles:
+  staging:
+    server_url: https://staging.example.com/cobbler_api
+    password_command: echo staging-secret
+  lab:
+    server_username: lab
+`
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cobbler.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("writing config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadConfigFile_topLevel(t *testing.T) {
+	profile, err := client.LoadConfigFile(writeConfigFile(t, testConfigFile), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.URL != "http://cobbler.example.com/cobbler_api" || profile.Username != "cobbler" || profile.Password != "default-secret" {
+		t.Errorf("unexpected profile %+v", profile)
+	}
+	if profile.Insecure == nil || !*profile.Insecure {
+		t.Error("expected insecure to be read")
+	}
+}
+
+func TestLoadConfigFile_namedProfile(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	staging, err := client.LoadConfigFile(path, "staging")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if staging.URL != "https://staging.example.com/cobbler_api" {
+		t.Errorf("expected the profile URL, got %q", staging.URL)
+	}
+	if staging.Username != "cobbler" {
+		t.Errorf("expected the top-level username as default, got %q", staging.Username)
+	}
+	if staging.Password != "" || staging.PasswordCommand != "echo staging-secret" {
+		t.Errorf("expected the profile's own password source only, got %+v", staging)
+	}
+
+	lab, err := client.LoadConfigFile(path, "lab")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if lab.Username != "lab" || lab.Password != "default-secret" {
+		t.Errorf("unexpected profile %+v", lab)
+	}
+}
+
+func TestLoadConfigFile_errors(t *testing.T) {
+	path := writeConfigFile(t, testConfigFile)
+
+	if _, err := client.LoadConfigFile(path, "missing"); err == nil || !strings.Contains(err.Error(), "available: lab, staging") {
+		t.Errorf("expected an unknown profile error listing the profiles, got %v", err)
+	}
+	if _, err := client.LoadConfigFile(filepath.Join(t.TempDir(), "missing.yaml"), ""); err == nil {
+		t.Error("expected an error for a missing explicit config file")
+	}
+	if _, err := client.LoadConfigFile(writeConfigFile(t, "profiles: [unclosed"), ""); err == nil {
+		t.Error("expected an error for invalid YAML")
+	}
+}
+
+func TestLoadConfigFile_defaultMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", os.Getenv("HOME"))
+
+	profile, err := client.LoadConfigFile("", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile != (client.FileProfile{}) {
+		t.Errorf("expected an empty profile, got %+v", profile)
+	}
+	if _, err := client.LoadConfigFile("", "staging"); err == nil {
+		t.Error("expected an error for a profile without a config file")
+	}
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(path, []byte("s3cret\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	password, err := client.ReadPasswordFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("expected %q, got %q", "s3cret", password)
+	}
+}
+
+func TestRunPasswordCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a POSIX shell")
+	}
+
+	password, err := client.RunPasswordCommand(context.Background(), "echo s3cret")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password != "s3cret" {
+		t.Errorf("expected %q, got %q", "s3cret", password)
+	}
+
+	if _, err := client.RunPasswordCommand(context.Background(), "echo nope >&2; exit 3"); err == nil || !strings.Contains(err.Error(), "nope") {
+		t.Errorf("expected the command's stderr in the error, got %v", err)
+	}
+}
@@ -2,13 +2,34 @@ package client
 
 import (
 	"errors"
+	"reflect"
+	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 )
 
+// AttributePaths maps Cobbler item field names to the schema paths a resource exposes them at.
+type AttributePaths map[string]path.Path
+
+// AttributePathsFromModel maps every tfsdk-tagged field of the model struct to the top-level
+// attribute of the same name. Cobbler fields that live elsewhere in the schema can be added
+// to the returned map.
+func AttributePathsFromModel(model any) AttributePaths {
+	t := reflect.TypeOf(model)
+	paths := make(AttributePaths, t.NumField())
+	for i := range t.NumField() {
+		if tag := t.Field(i).Tag.Get("tfsdk"); tag != "" && tag != "-" {
+			paths[tag] = path.Root(tag)
+		}
+	}
+	return paths
+}
+
 // AddClientError appends err to diags. For InheritanceUnsupportedError it uses a descriptive
-// summary so the user can immediately identify which field to fix and set explicitly.
+// summary so the user can immediately identify which field to fix and set explicitly. Login
+// failures, which surface on whichever API call happens to come first, get their own summary.
 func AddClientError(diags *diag.Diagnostics, summary string, err error) {
 	var ie *cobbler.InheritanceUnsupportedError
 	if errors.As(err, &ie) {
@@ -18,5 +39,36 @@ func AddClientError(diags *diag.Diagnostics, summary string, err error) {
 		)
 		return
 	}
+	var le *LoginError
+	if errors.As(err, &le) || strings.Contains(err.Error(), loginErrorPrefix) {
+		diags.AddError(
+			"Failed to log in to Cobbler",
+			"The provider logs in to Cobbler on its first API call. Check the url, username and password "+
+				"provider settings and that the Cobbler server is reachable.\n\n"+err.Error(),
+		)
+		return
+	}
+	if IsReadOnly(err) {
+		diags.AddError(
+			"Cobbler provider is read-only",
+			"This operation would modify Cobbler, but the provider is configured with read_only. "+
+				"Unset read_only (or COBBLER_READ_ONLY) to apply changes.\n\n"+err.Error(),
+		)
+		return
+	}
 	diags.AddError(summary, err.Error())
 }
+
+// AddItemError is AddClientError for operations on a Cobbler item. A validation fault that
+// names a field present in paths is reported against that attribute, so Terraform points
+// at the offending configuration instead of the resource as a whole.
+func AddItemError(diags *diag.Diagnostics, summary string, err error, paths AttributePaths) {
+	var fe *FaultError
+	if errors.As(Classify(err), &fe) && fe.Field != "" {
+		if p, ok := paths[fe.Field]; ok {
+			diags.AddAttributeError(p, summary, fe.Message)
+			return
+		}
+	}
+	AddClientError(diags, summary, err)
+}
@@ -8,6 +8,8 @@ import (
 	cobbler "github.com/cobbler/cobblerclient"
 	"github.com/cobbler/terraform-provider-cobbler/internal/client"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 func TestAddClientError_genericError(t *testing.T) {
@@ -78,3 +80,106 @@ func TestAddClientError_wrappedInheritanceUnsupported(t *testing.T) {
 		t.Errorf("expected detail %q, got %q", ie.Error(), diags[0].Detail())
 	}
 }
+
+func TestAddClientError_loginFailed(t *testing.T) {
+	for name, err := range map[string]error{
+		"wrapped":   fmt.Errorf("reading system: %w", &client.LoginError{Err: errors.New("login failed")}),
+		"flattened": errors.New(`Post "https://cobbler/cobbler_api": cobbler login failed: Fault(1): login failed`),
+	} {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			client.AddClientError(&diags, "Error reading Cobbler System", err)
+
+			if len(diags) != 1 {
+				t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+			}
+			if diags[0].Summary() != "Failed to log in to Cobbler" {
+				t.Errorf("unexpected summary %q", diags[0].Summary())
+			}
+		})
+	}
+}
+
+func TestAddClientError_readOnly(t *testing.T) {
+	var diags diag.Diagnostics
+	client.AddClientError(&diags, "Error creating Cobbler System", fmt.Errorf("%w: refusing to call new_system", client.ErrReadOnly))
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if diags[0].Summary() != "Cobbler provider is read-only" {
+		t.Errorf("unexpected summary %q", diags[0].Summary())
+	}
+}
+
+type testModel struct {
+	Name     types.String `tfsdk:"name"`
+	VirtType types.String `tfsdk:"virt_type"`
+	Ignored  string
+}
+
+func TestAttributePathsFromModel(t *testing.T) {
+	paths := client.AttributePathsFromModel(testModel{})
+
+	if len(paths) != 2 {
+		t.Fatalf("expected 2 paths, got %d", len(paths))
+	}
+	if !paths["virt_type"].Equal(path.Root("virt_type")) {
+		t.Errorf("unexpected path for virt_type: %s", paths["virt_type"])
+	}
+}
+
+func TestAddItemError_validationField(t *testing.T) {
+	paths := client.AttributePathsFromModel(testModel{})
+
+	var diags diag.Diagnostics
+	client.AddItemError(&diags, "Error creating Cobbler System", loadFault(t, "validation_virt_type"), paths)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	withPath, ok := diags[0].(diag.DiagnosticWithPath)
+	if !ok {
+		t.Fatal("expected an attribute-scoped diagnostic")
+	}
+	if !withPath.Path().Equal(path.Root("virt_type")) {
+		t.Errorf("expected path virt_type, got %s", withPath.Path())
+	}
+	if diags[0].Summary() != "Error creating Cobbler System" {
+		t.Errorf("unexpected summary %q", diags[0].Summary())
+	}
+	wantDetail := "virt_type choices include: kvm, qemu, xenpv, xenfv, vmware, vmwarew, openvz, auto"
+	if diags[0].Detail() != wantDetail {
+		t.Errorf("expected detail %q, got %q", wantDetail, diags[0].Detail())
+	}
+}
+
+func TestAddItemError_unknownField(t *testing.T) {
+	paths := client.AttributePathsFromModel(testModel{})
+
+	var diags diag.Diagnostics
+	fault := loadFault(t, "validation_mac")
+	client.AddItemError(&diags, "Error creating Cobbler System", fault, paths)
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+		t.Error("expected a resource-level diagnostic for a field the resource does not expose")
+	}
+	if diags[0].Detail() != fault.Error() {
+		t.Errorf("expected detail %q, got %q", fault.Error(), diags[0].Detail())
+	}
+}
+
+func TestAddItemError_notValidation(t *testing.T) {
+	var diags diag.Diagnostics
+	client.AddItemError(&diags, "Error reading Cobbler System", loadFault(t, "server_key_error"), client.AttributePathsFromModel(testModel{}))
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d", len(diags))
+	}
+	if _, ok := diags[0].(diag.DiagnosticWithPath); ok {
+		t.Error("expected a resource-level diagnostic")
+	}
+}
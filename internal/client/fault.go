@@ -0,0 +1,234 @@
+package client
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/kolo/xmlrpc"
+)
+
+// Sentinel errors for the kinds of failure the Cobbler API reports. Test for them with
+// errors.Is (or the Is* helpers below); Classify attaches them to raw client errors.
+var (
+	ErrNotFound         = errors.New("cobbler object not found")
+	ErrPermissionDenied = errors.New("cobbler permission denied")
+	ErrValidation       = errors.New("cobbler validation failed")
+	ErrConflict         = errors.New("cobbler object conflict")
+	ErrServer           = errors.New("cobbler server error")
+)
+
+// FaultError is a Cobbler XML-RPC fault (or client-side equivalent) that has been
+// classified into one of the sentinel error kinds.
+type FaultError struct {
+	// Kind is one of ErrNotFound, ErrPermissionDenied, ErrValidation, ErrConflict or ErrServer.
+	Kind error
+	// Code is the XML-RPC fault code, or 0 if the error did not come from a fault.
+	Code int
+	// Exception is the Python exception class Cobbler raised, e.g. "cobbler.cexceptions.CX".
+	Exception string
+	// Message is the exception message without the exception class prefix.
+	Message string
+	// Field is the Cobbler item field a validation fault refers to, normalized to
+	// snake_case (e.g. "virt_type", "mac_address"), or "" if none could be identified.
+	Field string
+
+	err error
+}
+
+func (e *FaultError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap exposes both the classification and the original client error to errors.Is/As.
+func (e *FaultError) Unwrap() []error {
+	return []error{e.Kind, e.err}
+}
+
+var (
+	// faultStringRx matches a fault that was flattened into an error string, e.g.
+	// "Fault(1): <class 'cobbler.cexceptions.CX'>:'...'".
+	faultStringRx = regexp.MustCompile(`(?s)Fault\((-?\d+)\): (.*)`)
+	// exceptionRx splits the Python exception class from the message in a faultString.
+	exceptionRx = regexp.MustCompile(`(?s)^<(?:class|type) '([^']+)'>:(.*)$`)
+	// clientNotFoundRx matches the errors cobblerclient itself returns for missing items.
+	clientNotFoundRx = regexp.MustCompile(`(?i)\bnot found$`)
+	// fieldRxs extract the offending field from the validation messages Cobbler produces,
+	// e.g. "Field enable_ipxe of object profile needs to be of type bool!",
+	// "invalid format for MAC address (aa:bb)" or "virt_type choices include: ...".
+	fieldRxs = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^field ['"]?([a-z0-9_ ]+?)['"]? of object `),
+		regexp.MustCompile(`(?i)^(?:invalid|bad) (?:format for |value for |value of )?['"]?([a-z][a-z0-9_]*(?: (?:address|name|type|id))?)['"]?\b`),
+		regexp.MustCompile(`(?i)^['"]?([a-z][a-z0-9_]*)['"]? (?:choices (?:are|include)|must be|must not|needs to be|has to be|should be|is not a valid|cannot be)\b`),
+		regexp.MustCompile(`(?i)^(?:the )?(profile|distro|image|parent|menu|system) (?:with the name |with uid |)['"]?[^'"]*['"]? (?:is not present|does not exist)`),
+	}
+)
+
+// Message prefixes Cobbler uses for each kind of failure. Only the start of the message is
+// matched, so item names embedded later in the message cannot change the classification.
+var faultPrefixes = []struct {
+	kind     error
+	prefixes []string
+}{
+	{ErrNotFound, []string{
+		"internal error, unknown ",
+		"unknown ",
+		"no such ",
+		"item not found",
+		"object not found",
+		"could not find ",
+	}},
+	{ErrPermissionDenied, []string{
+		"invalid token",
+		"login failed",
+		"authorization failure",
+		"permission denied",
+		"not authorized",
+		"unauthorized",
+	}},
+	{ErrConflict, []string{
+		"an object already exists",
+		"duplicate ",
+		"mac address duplicated",
+		"ip address duplicated",
+		"dns name duplicated",
+		"removal would orphan ",
+		"cannot delete, ",
+	}},
+	{ErrValidation, []string{
+		"invalid ",
+		"validation error",
+		"value must be ",
+	}},
+}
+
+// validationExceptions are Python exceptions Cobbler's property setters raise for bad input.
+var validationExceptions = []string{"ValueError", "TypeError", "SyntaxError"}
+
+// Classify inspects err and, if it is a Cobbler fault or a recognized client error, returns
+// a *FaultError carrying its kind. Other errors (network failures, nil) are returned as-is.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var fe *FaultError
+	if errors.As(err, &fe) {
+		return err
+	}
+
+	code, faultString, ok := extractFault(err)
+	if !ok {
+		if clientNotFoundRx.MatchString(strings.TrimSpace(innermost(err).Error())) {
+			return &FaultError{Kind: ErrNotFound, Message: innermost(err).Error(), err: err}
+		}
+		return err
+	}
+
+	exception, message := splitException(faultString)
+	fe = &FaultError{
+		Kind:      classifyFault(exception, message),
+		Code:      code,
+		Exception: exception,
+		Message:   message,
+		err:       err,
+	}
+	if fe.Kind == ErrValidation {
+		fe.Field = validationField(message)
+	}
+	return fe
+}
+
+// IsNotFound reports whether err means the requested Cobbler object does not exist.
+func IsNotFound(err error) bool {
+	return errors.Is(Classify(err), ErrNotFound)
+}
+
+// IsPermissionDenied reports whether Cobbler rejected the request's credentials or token.
+func IsPermissionDenied(err error) bool {
+	return errors.Is(Classify(err), ErrPermissionDenied)
+}
+
+// IsValidation reports whether Cobbler rejected a field value.
+func IsValidation(err error) bool {
+	return errors.Is(Classify(err), ErrValidation)
+}
+
+// IsConflict reports whether the request collides with an existing Cobbler object.
+func IsConflict(err error) bool {
+	return errors.Is(Classify(err), ErrConflict)
+}
+
+// extractFault returns the fault code and string carried by err, either as a typed
+// xmlrpc.FaultError or flattened into the error message by an intermediate wrapper.
+func extractFault(err error) (int, string, bool) {
+	var xf xmlrpc.FaultError
+	if errors.As(err, &xf) {
+		return xf.Code, xf.String, true
+	}
+	var xfp *xmlrpc.FaultError
+	if errors.As(err, &xfp) && xfp != nil {
+		return xfp.Code, xfp.String, true
+	}
+	if m := faultStringRx.FindStringSubmatch(err.Error()); m != nil {
+		code, _ := strconv.Atoi(m[1])
+		return code, m[2], true
+	}
+	return 0, "", false
+}
+
+// splitException separates "<class 'mod.Exc'>:'message'" into its class and message.
+func splitException(faultString string) (string, string) {
+	m := exceptionRx.FindStringSubmatch(strings.TrimSpace(faultString))
+	if m == nil {
+		return "", strings.TrimSpace(faultString)
+	}
+	message := strings.TrimSpace(m[2])
+	if len(message) >= 2 {
+		if q := message[0]; (q == '\'' || q == '"') && message[len(message)-1] == q {
+			message = message[1 : len(message)-1]
+		}
+	}
+	return m[1], message
+}
+
+func classifyFault(exception, message string) error {
+	short := exception[strings.LastIndex(exception, ".")+1:]
+	if slices.Contains(validationExceptions, short) {
+		return ErrValidation
+	}
+	lower := strings.ToLower(message)
+	for _, group := range faultPrefixes {
+		for _, prefix := range group.prefixes {
+			if strings.HasPrefix(lower, prefix) {
+				return group.kind
+			}
+		}
+	}
+	return ErrServer
+}
+
+// validationField returns the normalized field name a validation message refers to.
+// Prose references such as "profile name" or "MAC address" become "profile" and "mac_address".
+func validationField(message string) string {
+	for _, rx := range fieldRxs {
+		if m := rx.FindStringSubmatch(message); m != nil {
+			field := strings.ToLower(strings.TrimSpace(m[1]))
+			field = strings.TrimSuffix(strings.TrimSuffix(field, " name"), " id")
+			return strings.ReplaceAll(field, " ", "_")
+		}
+	}
+	return ""
+}
+
+// innermost returns the deepest error in err's single-wrap chain.
+func innermost(err error) error {
+	for {
+		next := errors.Unwrap(err)
+		if next == nil {
+			return err
+		}
+		err = next
+	}
+}
@@ -0,0 +1,154 @@
+package client_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/kolo/xmlrpc"
+)
+
+// loadFault decodes a captured Cobbler XML-RPC fault response from testdata/faults.
+func loadFault(t *testing.T, name string) error {
+	t.Helper()
+	body, err := os.ReadFile(filepath.Join("testdata", "faults", name+".xml"))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	fault := xmlrpc.Response(body).Err()
+	if fault == nil {
+		t.Fatalf("fixture %s does not contain a fault", name)
+	}
+	return fault
+}
+
+func TestClassify_capturedFaults(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    error
+	}{
+		{"not_found_unknown_system", client.ErrNotFound},
+		{"not_found_unknown_distro", client.ErrNotFound},
+		{"permission_invalid_token", client.ErrPermissionDenied},
+		{"permission_login_failed", client.ErrPermissionDenied},
+		{"permission_authorization", client.ErrPermissionDenied},
+		{"validation_virt_type", client.ErrValidation},
+		{"validation_mac", client.ErrValidation},
+		{"validation_type_error", client.ErrValidation},
+		{"validation_name_contains_not_found", client.ErrValidation},
+		{"conflict_exists", client.ErrConflict},
+		{"conflict_mac", client.ErrConflict},
+		{"server_key_error", client.ErrServer},
+		{"server_attribute_error", client.ErrServer},
+	}
+	kinds := []error{client.ErrNotFound, client.ErrPermissionDenied, client.ErrValidation, client.ErrConflict, client.ErrServer}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			fault := loadFault(t, tt.fixture)
+			classified := client.Classify(fault)
+
+			for _, kind := range kinds {
+				if got := errors.Is(classified, kind); got != (kind == tt.want) {
+					t.Errorf("errors.Is(%v) = %v, want %v", kind, got, kind == tt.want)
+				}
+			}
+			var xf xmlrpc.FaultError
+			if !errors.As(classified, &xf) {
+				t.Error("expected the original xmlrpc.FaultError to stay reachable")
+			}
+			if classified.Error() != fault.Error() {
+				t.Errorf("expected message %q to be preserved, got %q", fault.Error(), classified.Error())
+			}
+		})
+	}
+}
+
+func TestClassify_faultDetails(t *testing.T) {
+	var fe *client.FaultError
+	if !errors.As(client.Classify(loadFault(t, "not_found_unknown_system")), &fe) {
+		t.Fatal("expected a *client.FaultError")
+	}
+	if fe.Code != 1 {
+		t.Errorf("expected code 1, got %d", fe.Code)
+	}
+	if fe.Exception != "cobbler.cexceptions.CX" {
+		t.Errorf("unexpected exception %q", fe.Exception)
+	}
+	if fe.Message != "internal error, unknown system name web01" {
+		t.Errorf("unexpected message %q", fe.Message)
+	}
+}
+
+func TestClassify_validationField(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    string
+	}{
+		{"validation_virt_type", "virt_type"},
+		{"validation_mac", "mac_address"},
+		{"validation_type_error", "enable_ipxe"},
+		{"validation_name_contains_not_found", "profile"},
+		{"not_found_unknown_system", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			var fe *client.FaultError
+			if !errors.As(client.Classify(loadFault(t, tt.fixture)), &fe) {
+				t.Fatal("expected a *client.FaultError")
+			}
+			if fe.Field != tt.want {
+				t.Errorf("expected field %q, got %q", tt.want, fe.Field)
+			}
+		})
+	}
+}
+
+func TestClassify_flattenedFault(t *testing.T) {
+	err := fmt.Errorf("error getting system: %s", loadFault(t, "not_found_unknown_system"))
+	if !client.IsNotFound(err) {
+		t.Errorf("expected a fault flattened into a string to be classified, got %v", client.Classify(err))
+	}
+}
+
+func TestClassify_wrappedFault(t *testing.T) {
+	err := fmt.Errorf("outer context: %w", loadFault(t, "conflict_exists"))
+	if !client.IsConflict(err) {
+		t.Errorf("expected wrapped fault to be classified as conflict")
+	}
+}
+
+func TestClassify_clientNotFound(t *testing.T) {
+	if !client.IsNotFound(errors.New("system web01 not found")) {
+		t.Error("expected cobblerclient's not-found error to be classified")
+	}
+	if client.IsNotFound(errors.New("profile not found-profile is invalid")) {
+		t.Error("expected a name containing \"not found\" not to be classified as not found")
+	}
+}
+
+func TestClassify_unclassified(t *testing.T) {
+	if err := client.Classify(nil); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+	netErr := errors.New("dial tcp 127.0.0.1:25151: connect: connection refused")
+	if got := client.Classify(netErr); got != netErr {
+		t.Errorf("expected network error to be returned unchanged, got %v", got)
+	}
+}
+
+func TestIsHelpers(t *testing.T) {
+	if !client.IsPermissionDenied(loadFault(t, "permission_invalid_token")) {
+		t.Error("expected IsPermissionDenied")
+	}
+	if !client.IsValidation(loadFault(t, "validation_virt_type")) {
+		t.Error("expected IsValidation")
+	}
+	if client.IsNotFound(loadFault(t, "validation_name_contains_not_found")) {
+		t.Error("a validation fault mentioning \"not found\" must not be treated as not found")
+	}
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	cobbler "github.com/cobbler/cobblerclient"
+)
+
+// ItemName returns the name of the Cobbler item of type what (e.g. "system") with the given
+// UID. Resources reference items by UID, while several Cobbler APIs, such as buildiso and
+// power management, take names. An unknown UID is reported as ErrNotFound.
+func ItemName(client cobbler.Client, what, uid string) (string, error) {
+	result, err := client.Call("find_"+what, map[string]interface{}{"uid": uid})
+	if err != nil {
+		return "", err
+	}
+	if found, _ := result.([]interface{}); len(found) > 0 {
+		if name, ok := found[0].(string); ok && name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("%w: no %s with UID %q", ErrNotFound, what, uid)
+}
+
+// BlendedData returns the data Cobbler renders templates and boot files with for the named
+// item of type what ("profile", "system" or "image"): its own fields merged with everything
+// it inherits from parent profiles, the distro and the settings. Cobbler sends None values
+// as "~". An unknown name is reported as ErrNotFound.
+func BlendedData(client cobbler.Client, what, name string) (map[string]interface{}, error) {
+	var result interface{}
+	var err error
+	switch what {
+	case "profile":
+		result, err = client.Call("get_blended_data", name, "")
+	case "system":
+		result, err = client.Call("get_blended_data", "", name)
+	case "image":
+		// get_blended_data only takes profiles and systems; an image inherits from the
+		// settings alone, which its resolved item already applies.
+		result, err = client.Call("get_item", "image", name, false, true)
+	default:
+		return nil, fmt.Errorf("cobbler has no blended data for %s items", what)
+	}
+	if err != nil {
+		if IsNotFound(err) || strings.Contains(err.Error(), what+" not found") {
+			return nil, fmt.Errorf("%w: no %s named %q", ErrNotFound, what, name)
+		}
+		return nil, err
+	}
+	data, ok := result.(map[string]interface{})
+	if !ok {
+		if result == "~" {
+			return nil, fmt.Errorf("%w: no %s named %q", ErrNotFound, what, name)
+		}
+		return nil, fmt.Errorf("unexpected blended data of type %T", result)
+	}
+	return data, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import "sync"
+
+// PlannedChanges records, while Terraform plans, the items whose effective values change, by
+// UID and name. Terraform plans an item before the items referencing it, so they can look up
+// whether a parent changes even though the reference itself does not.
+type PlannedChanges struct {
+	mu    sync.Mutex
+	items map[string]struct{}
+}
+
+// Add records that the item referenced by ref plans new effective values.
+func (c *PlannedChanges) Add(ref string) {
+	if c == nil || ref == "" {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.items == nil {
+		c.items = make(map[string]struct{})
+	}
+	c.items[ref] = struct{}{}
+}
+
+// Has reports whether the item referenced by ref plans new effective values.
+func (c *PlannedChanges) Has(ref string) bool {
+	if c == nil || ref == "" {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.items[ref]
+	return ok
+}
@@ -0,0 +1,23 @@
+package client
+
+import "testing"
+
+func TestPlannedChanges(t *testing.T) {
+	if (*PlannedChanges)(nil).Has("u1") {
+		t.Error("expected a nil tracker to have no changes")
+	}
+	(*PlannedChanges)(nil).Add("u1")
+
+	changes := &PlannedChanges{}
+	if changes.Has("u1") {
+		t.Error("expected no changes before Add")
+	}
+	changes.Add("u1")
+	changes.Add("")
+	if !changes.Has("u1") {
+		t.Error("expected u1 to be recorded")
+	}
+	if changes.Has("") || changes.Has("u2") {
+		t.Error("expected only u1 to be recorded")
+	}
+}
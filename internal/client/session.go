@@ -0,0 +1,351 @@
+package client
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/kolo/xmlrpc"
+)
+
+const (
+	// DefaultMaxRetries is the default number of retries for a failed idempotent request.
+	DefaultMaxRetries = 3
+	// DefaultRetryMaxWait is the default cap on the backoff between two retries.
+	DefaultRetryMaxWait = 30 * time.Second
+	// retryMinWait is the backoff before the first retry; it doubles for every further one.
+	retryMinWait = time.Second
+	// pendingToken is the token cobblerclient.Client values carry until the session has
+	// logged in. The transport replaces it with the session's real token.
+	pendingToken = "terraform-provider-cobbler-pending-login"
+	// loginErrorPrefix starts every LoginError message, so the error can still be recognized
+	// after a client library flattened it into a string.
+	loginErrorPrefix = "cobbler login failed: "
+)
+
+// None is sent to Cobbler as XML-RPC nil, which Cobbler stores as Python's None, e.g. for a
+// flag-only kernel option. kolo/xmlrpc encodes Go's nil as an empty <value/>, which Python
+// reads as an empty string, so callers put this placeholder into the maps they send and the
+// transport rewrites it. It is only rewritten as the value of a struct member in calls that
+// create or modify items; anywhere else it is sent as is.
+const None = "terraform-provider-cobbler-none"
+
+// noneMethodPrefixes lists the XML-RPC methods whose map arguments may hold None.
+var noneMethodPrefixes = []string{"modify_", "new_"}
+
+// noneMemberRx matches a struct member holding None, as kolo/xmlrpc encodes it.
+var noneMemberRx = regexp.MustCompile(`(<member><name>[^<]*</name><value>)<string>` + regexp.QuoteMeta(None) + `</string>(</value></member>)`)
+
+// LoginError is returned by API calls when the provider could not log in to Cobbler.
+type LoginError struct {
+	Err error
+}
+
+func (e *LoginError) Error() string {
+	return loginErrorPrefix + e.Err.Error()
+}
+
+func (e *LoginError) Unwrap() error {
+	return e.Err
+}
+
+// idempotentMethodPrefixes lists the XML-RPC methods that can safely be replayed when it is
+// unknown whether Cobbler already processed the first attempt.
+var idempotentMethodPrefixes = []string{
+	"check_access",
+	"extended_version",
+	"find_",
+	"generate_",
+	"get_",
+	"has_item",
+	"is_",
+	"last_modified_time",
+	"login",
+	"modify_",
+	"ping",
+	"sync",
+	"token_check",
+	"version",
+}
+
+var methodNameRx = regexp.MustCompile(`<methodName>\s*([^<\s]+)\s*</methodName>`)
+
+// sessionTransport is the http.RoundTripper every Cobbler XML-RPC request goes through. It
+// keeps the provider's login session alive and rides out short cobblerd outages:
+//
+//   - cobblerclient.Client values are copied into every resource, so the token they carry
+//     cannot be refreshed after Configure. They carry a placeholder instead, which is
+//     rewritten to the session's current token in every request.
+//   - The session logs in on the first request that needs a token, so configuring the
+//     provider does not require a reachable Cobbler server.
+//   - A fault saying the token is invalid (Cobbler expires idle tokens) triggers a single
+//     re-login, after which the request is replayed.
+//   - Connection failures and gateway errors are retried with exponential backoff. Requests
+//     that may already have reached Cobbler are only retried for idempotent methods.
+//   - The None placeholder in the maps sent to modify_* and new_* calls is rewritten to an
+//     XML-RPC nil.
+type sessionTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+
+	// login performs a fresh login and returns the new token.
+	login func() (string, error)
+	// loginMu serializes re-logins so concurrent requests with an expired token share one.
+	loginMu sync.Mutex
+
+	mu sync.Mutex
+	// initial is the token the cobblerclient.Client values were created with.
+	initial string
+	// current is the token requests are actually sent with, or "" before the first login.
+	current string
+	// loginErr is the error of a failed login. It is returned for every later request so
+	// that a wrong password is not retried against the server over and over again.
+	loginErr error
+}
+
+func newSessionTransport(base http.RoundTripper, maxRetries int, maxWait time.Duration) *sessionTransport {
+	if maxRetries < 0 {
+		maxRetries = 0
+	}
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+	return &sessionTransport{
+		base:       base,
+		maxRetries: maxRetries,
+		minWait:    min(retryMinWait, maxWait),
+		maxWait:    maxWait,
+	}
+}
+
+// start records the placeholder token the clients were created with and how to log in.
+func (t *sessionTransport) start(token string, login func() (string, error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.initial = token
+	t.current = ""
+	t.loginErr = nil
+	t.login = login
+}
+
+func (t *sessionTransport) tokens() (string, string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.initial, t.current
+}
+
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	method := methodName(body)
+	body = rewriteNone(method, body)
+
+	initial, _ := t.tokens()
+	usesToken := initial != "" && bytes.Contains(body, tokenArg(initial))
+
+	for relogged := false; ; relogged = true {
+		sent := body
+		_, current := t.tokens()
+		if usesToken && current == "" {
+			tflog.Debug(req.Context(), "Logging in to Cobbler")
+			if err := t.relogin(""); err != nil {
+				return nil, err
+			}
+			_, current = t.tokens()
+		}
+		if usesToken {
+			sent = bytes.ReplaceAll(body, tokenArg(initial), tokenArg(current))
+		}
+
+		resp, err := t.send(req, sent, method)
+		if err != nil || !usesToken || relogged {
+			return resp, err
+		}
+		expired, err := hasInvalidTokenFault(resp)
+		if err != nil || !expired {
+			return resp, err
+		}
+		_ = resp.Body.Close()
+
+		tflog.Info(req.Context(), "Cobbler token expired, logging in again", map[string]interface{}{"method": method})
+		if err := t.relogin(current); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// relogin replaces the stale token with a new one, unless a concurrent request already did.
+// A stale token of "" performs the initial login.
+func (t *sessionTransport) relogin(stale string) error {
+	t.loginMu.Lock()
+	defer t.loginMu.Unlock()
+
+	t.mu.Lock()
+	current, loginErr, login := t.current, t.loginErr, t.login
+	t.mu.Unlock()
+	if loginErr != nil {
+		return loginErr
+	}
+	if current != stale {
+		return nil
+	}
+	if login == nil {
+		return &LoginError{Err: errors.New("no login is configured")}
+	}
+
+	token, err := login()
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		t.loginErr = &LoginError{Err: err}
+		return t.loginErr
+	}
+	t.current = token
+	return nil
+}
+
+// send performs a single XML-RPC request, retrying transient failures with backoff.
+func (t *sessionTransport) send(req *http.Request, body []byte, method string) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		r := req.Clone(ctx)
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.GetBody = func() (io.ReadCloser, error) { return io.NopCloser(bytes.NewReader(body)), nil }
+		r.ContentLength = int64(len(body))
+
+		resp, err := t.base.RoundTrip(r)
+		if attempt >= t.maxRetries || ctx.Err() != nil || !retryable(method, resp, err) {
+			return resp, err
+		}
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		wait := t.backoff(attempt)
+		tflog.Warn(ctx, "Retrying Cobbler request after transient failure", map[string]interface{}{
+			"method":  method,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"reason":  reason,
+		})
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// backoff returns the wait before retry number attempt+1.
+func (t *sessionTransport) backoff(attempt int) time.Duration {
+	wait := t.minWait
+	for i := 0; i < attempt && wait < t.maxWait; i++ {
+		wait *= 2
+	}
+	return min(wait, t.maxWait)
+}
+
+// retryable reports whether a failed attempt may be retried. Requests that never reached
+// Cobbler (refused connections, an unavailable gateway) can always be retried; everything
+// else, including attempts that ran into request_timeout, only for idempotent methods.
+func retryable(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return isIdempotent(method)
+	}
+	switch resp.StatusCode {
+	case http.StatusServiceUnavailable:
+		return true
+	case http.StatusBadGateway, http.StatusGatewayTimeout:
+		return isIdempotent(method)
+	}
+	return false
+}
+
+func isIdempotent(method string) bool {
+	for _, prefix := range idempotentMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasInvalidTokenFault reports whether resp is a fault rejecting the request's token. The
+// response body is buffered so the caller can still read it.
+func hasInvalidTokenFault(resp *http.Response) (bool, error) {
+	if resp.StatusCode != http.StatusOK {
+		return false, nil
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	if !bytes.Contains(body, []byte("<fault>")) {
+		return false, nil
+	}
+	var fe *FaultError
+	if !errors.As(Classify(xmlrpc.Response(body).Err()), &fe) {
+		return false, nil
+	}
+	return strings.HasPrefix(strings.ToLower(fe.Message), "invalid token"), nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	defer func() { _ = req.Body.Close() }()
+	return io.ReadAll(req.Body)
+}
+
+func methodName(body []byte) string {
+	if m := methodNameRx.FindSubmatch(body); m != nil {
+		return string(m[1])
+	}
+	return ""
+}
+
+// rewriteNone replaces the struct members holding None in a request to method with XML-RPC
+// nils.
+func rewriteNone(method string, body []byte) []byte {
+	for _, prefix := range noneMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return noneMemberRx.ReplaceAll(body, []byte("${1}<nil/>${2}"))
+		}
+	}
+	return body
+}
+
+// tokenArg returns token encoded the way kolo/xmlrpc encodes a string parameter.
+func tokenArg(token string) []byte {
+	var b bytes.Buffer
+	b.WriteString("<string>")
+	_ = xml.EscapeText(&b, []byte(token))
+	b.WriteString("</string>")
+	return b.Bytes()
+}
@@ -0,0 +1,297 @@
+package client
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kolo/xmlrpc"
+)
+
+const invalidTokenFault = `<?xml version='1.0'?><methodResponse><fault><value><struct>` +
+	`<member><name>faultCode</name><value><int>1</int></value></member>` +
+	`<member><name>faultString</name><value><string>&lt;class 'cobbler.cexceptions.CX'&gt;:'invalid token: %s'</string></value></member>` +
+	`</struct></value></fault></methodResponse>`
+
+const okResponse = `<?xml version='1.0'?><methodResponse><params><param><value><string>ok</string></value></param></params></methodResponse>`
+
+func newTestSession(maxRetries int) *sessionTransport {
+	s := newSessionTransport(http.DefaultTransport, maxRetries, 4*time.Millisecond)
+	s.minWait = time.Millisecond
+	return s
+}
+
+// call performs a single XML-RPC call through transport and returns the decoded result.
+func call(t *testing.T, transport http.RoundTripper, url, method string, args ...interface{}) (string, error) {
+	t.Helper()
+	body, err := xmlrpc.EncodeMethodCall(method, args...)
+	if err != nil {
+		t.Fatalf("encoding request: %v", err)
+	}
+	resp, err := (&http.Client{Transport: transport}).Post(url, "text/xml", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("reading response: %v", err)
+	}
+	if err := xmlrpc.Response(respBody).Err(); err != nil {
+		return "", err
+	}
+	var result string
+	err = xmlrpc.Response(respBody).Unmarshal(&result)
+	return result, err
+}
+
+func TestSessionTransport_relogin(t *testing.T) {
+	var accepted atomic.Value
+	accepted.Store("t2")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		token := accepted.Load().(string)
+		if !bytes.Contains(body, tokenArg(token)) {
+			_, _ = fmt.Fprintf(w, invalidTokenFault, "stale")
+			return
+		}
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	var logins atomic.Int32
+	session := newTestSession(0)
+	session.start("t1", func() (string, error) {
+		logins.Add(1)
+		return accepted.Load().(string), nil
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := call(t, session, server.URL, "get_system", "foo", "t1"); err != nil {
+			t.Fatalf("call %d: unexpected error: %v", i, err)
+		}
+	}
+	if n := logins.Load(); n != 1 {
+		t.Errorf("expected 1 re-login, got %d", n)
+	}
+
+	// The new token expires as well.
+	accepted.Store("t3")
+	if _, err := call(t, session, server.URL, "remove_system", "foo", "t1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := logins.Load(); n != 2 {
+		t.Errorf("expected 2 re-logins, got %d", n)
+	}
+}
+
+func TestSessionTransport_lazyLogin(t *testing.T) {
+	var tokens []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if bytes.Contains(body, tokenArg("t2")) {
+			tokens = append(tokens, "t2")
+		}
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	var logins atomic.Int32
+	session := newTestSession(0)
+	session.start(pendingToken, func() (string, error) {
+		logins.Add(1)
+		return "t2", nil
+	})
+
+	if _, err := call(t, session, server.URL, "version"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n := logins.Load(); n != 0 {
+		t.Fatalf("expected no login for a call without a token, got %d", n)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := call(t, session, server.URL, "get_system", "foo", pendingToken); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if n := logins.Load(); n != 1 {
+		t.Errorf("expected 1 login, got %d", n)
+	}
+	if len(tokens) != 2 {
+		t.Errorf("expected both calls to carry the session token, got %v", tokens)
+	}
+}
+
+func TestSessionTransport_none(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	options := map[string]interface{}{"quiet": None, "console": "ttyS0"}
+	if _, err := call(t, newTestSession(0), server.URL, "modify_system", "handle", "kernel_options", options, pendingToken); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.Contains(body, []byte("<name>quiet</name><value><nil/></value>")) {
+		t.Errorf("expected quiet to be sent as nil, got %s", body)
+	}
+	if !bytes.Contains(body, []byte("<string>ttyS0</string>")) || bytes.Contains(body, []byte(None)) {
+		t.Errorf("expected only the placeholder to be rewritten, got %s", body)
+	}
+}
+
+func TestSessionTransport_noneElsewhere(t *testing.T) {
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	tests := map[string][]interface{}{
+		"find_system":   {map[string]interface{}{"comment": None}},
+		"modify_system": {"handle", "comment", None, pendingToken},
+	}
+	for method, args := range tests {
+		t.Run(method, func(t *testing.T) {
+			if _, err := call(t, newTestSession(0), server.URL, method, args...); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if bytes.Contains(body, []byte("<nil/>")) || !bytes.Contains(body, []byte("<string>"+None+"</string>")) {
+				t.Errorf("expected the placeholder to be sent as is, got %s", body)
+			}
+		})
+	}
+}
+
+func TestSessionTransport_reloginFails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, invalidTokenFault, "t1")
+	}))
+	defer server.Close()
+
+	var logins atomic.Int32
+	session := newTestSession(0)
+	session.start("t1", func() (string, error) {
+		logins.Add(1)
+		return "", errors.New("login failed")
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := call(t, session, server.URL, "get_system", "foo", "t1")
+		var le *LoginError
+		if !errors.As(err, &le) {
+			t.Fatalf("call %d: expected a *LoginError, got %v", i, err)
+		}
+	}
+	if n := logins.Load(); n != 1 {
+		t.Errorf("expected a failed login not to be retried, got %d logins", n)
+	}
+}
+
+func TestSessionTransport_invalidTokenWithoutToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, invalidTokenFault, "x")
+	}))
+	defer server.Close()
+
+	var logins atomic.Int32
+	session := newTestSession(0)
+	session.start("t1", func() (string, error) {
+		logins.Add(1)
+		return "t2", nil
+	})
+
+	_, err := call(t, session, server.URL, "version")
+	if !IsPermissionDenied(err) {
+		t.Errorf("expected the fault to be passed through, got %v", err)
+	}
+	if n := logins.Load(); n != 0 {
+		t.Errorf("expected no re-login for a request without a token, got %d", n)
+	}
+}
+
+func TestSessionTransport_retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		failures  int32
+		wantCalls int32
+		wantErr   bool
+	}{
+		{"unavailable", "remove_system", http.StatusServiceUnavailable, 2, 3, false},
+		{"bad gateway idempotent", "get_system", http.StatusBadGateway, 2, 3, false},
+		{"bad gateway not idempotent", "remove_system", http.StatusBadGateway, 2, 1, true},
+		{"exhausted", "get_system", http.StatusServiceUnavailable, 10, 4, true},
+		{"server error", "get_system", http.StatusInternalServerError, 1, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					return
+				}
+				_, _ = io.WriteString(w, okResponse)
+			}))
+			defer server.Close()
+
+			_, err := call(t, newTestSession(3), server.URL, tt.method, "foo", "t1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if n := calls.Load(); n != tt.wantCalls {
+				t.Errorf("expected %d calls, got %d", tt.wantCalls, n)
+			}
+		})
+	}
+}
+
+func TestSessionTransport_retryRefusedConnection(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	var attempts atomic.Int32
+	session := newTestSession(2)
+	session.base = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		attempts.Add(1)
+		return http.DefaultTransport.RoundTrip(r)
+	})
+
+	// A refused connection never reached Cobbler, so even non-idempotent calls are retried.
+	if _, err := call(t, session, url, "remove_system", "foo", "t1"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("expected 3 attempts, got %d", n)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestSessionTransport_backoff(t *testing.T) {
+	s := newSessionTransport(http.DefaultTransport, 5, 5*time.Second)
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for attempt, w := range want {
+		if got := s.backoff(attempt); got != w {
+			t.Errorf("attempt %d: expected %s, got %s", attempt, w, got)
+		}
+	}
+}
@@ -0,0 +1,241 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Supported values for the provider-level sync_mode attribute.
+const (
+	// SyncModePerResource runs a Cobbler sync after every mutation.
+	SyncModePerResource = "per_resource"
+	// SyncModeCoalesced debounces concurrent mutations into a single shared sync.
+	SyncModeCoalesced = "coalesced"
+	// SyncModeNone never syncs; the operator is responsible for running `cobbler sync`.
+	SyncModeNone = "none"
+)
+
+// SyncModes lists every accepted sync_mode value.
+var SyncModes = []string{SyncModePerResource, SyncModeCoalesced, SyncModeNone}
+
+const (
+	// coalesceQuietPeriod is how long the syncer waits for further mutations before syncing.
+	coalesceQuietPeriod = 2 * time.Second
+	// coalesceMaxDelay caps how long a steady stream of mutations can postpone a sync.
+	coalesceMaxDelay = 30 * time.Second
+)
+
+// syncBackend performs the actual Cobbler sync calls.
+type syncBackend interface {
+	// SyncFull rebuilds every TFTP tree, boot menu and DHCP/DNS configuration.
+	SyncFull() error
+	// SyncSystems regenerates the boot files of the named systems only.
+	SyncSystems(names []string) error
+	// SyncDHCP regenerates and restarts the DHCP configuration only.
+	SyncDHCP() error
+}
+
+// cobblerSyncBackend is the syncBackend backed by the Cobbler XML-RPC API.
+type cobblerSyncBackend struct {
+	client cobbler.Client
+}
+
+func (b *cobblerSyncBackend) SyncFull() error {
+	return b.client.Sync()
+}
+
+func (b *cobblerSyncBackend) SyncSystems(names []string) error {
+	_, err := b.client.Call("sync_systems", names, b.client.Token)
+	return err
+}
+
+func (b *cobblerSyncBackend) SyncDHCP() error {
+	_, err := b.client.Call("sync_dhcp", b.client.Token)
+	return err
+}
+
+// syncRequest describes the work one or more mutations need from a sync.
+type syncRequest struct {
+	full    bool
+	dhcp    bool
+	systems map[string]struct{}
+}
+
+// merge folds other into r.
+func (r *syncRequest) merge(other syncRequest) {
+	r.full = r.full || other.full
+	r.dhcp = r.dhcp || other.dhcp
+	if len(other.systems) > 0 && r.systems == nil {
+		r.systems = make(map[string]struct{}, len(other.systems))
+	}
+	maps.Copy(r.systems, other.systems)
+}
+
+// Syncer runs Cobbler syncs on behalf of every resource of a single provider instance.
+//
+// Distro, profile, image, menu, template and repo changes need a full sync. System and network
+// interface changes only need Cobbler's per-system sync plus a DHCP sync, which is much
+// cheaper on servers with many systems. In coalesced mode all callers that request a sync
+// within the debounce window wait for, and share the result of, a single combined sync.
+// Syncs never run concurrently with each other.
+type Syncer struct {
+	mode      string
+	forceFull bool
+	readOnly  bool
+	backend   syncBackend
+
+	quietPeriod time.Duration
+	maxDelay    time.Duration
+
+	// running serializes syncs; Cobbler does not cope well with overlapping syncs.
+	running sync.Mutex
+
+	mu      sync.Mutex
+	pending *syncBatch
+}
+
+// syncBatch is a pending coalesced sync that any number of callers can wait on.
+type syncBatch struct {
+	req      syncRequest
+	timer    *time.Timer
+	deadline time.Time
+	done     chan struct{}
+	err      error
+}
+
+// NewSyncer returns a Syncer for client. An empty mode behaves like SyncModePerResource.
+// With forceFull set, targeted syncs are upgraded to full syncs.
+func NewSyncer(client cobbler.Client, mode string, forceFull bool) *Syncer {
+	if mode == "" {
+		mode = SyncModePerResource
+	}
+	return &Syncer{
+		mode:        mode,
+		forceFull:   forceFull,
+		backend:     &cobblerSyncBackend{client: client},
+		quietPeriod: coalesceQuietPeriod,
+		maxDelay:    coalesceMaxDelay,
+	}
+}
+
+// Sync requests a full Cobbler sync after a mutation and blocks until the sync covering
+// the caller's change has finished. A nil Syncer is a no-op.
+func (s *Syncer) Sync(ctx context.Context) error {
+	return s.request(ctx, syncRequest{full: true})
+}
+
+// SyncSystems requests a per-system sync of the named systems followed by a DHCP sync.
+func (s *Syncer) SyncSystems(ctx context.Context, names ...string) error {
+	req := syncRequest{dhcp: true, systems: make(map[string]struct{}, len(names))}
+	for _, name := range names {
+		if name != "" {
+			req.systems[name] = struct{}{}
+		}
+	}
+	return s.request(ctx, req)
+}
+
+// SyncDHCP requests a DHCP-only sync, e.g. after a system was removed.
+func (s *Syncer) SyncDHCP(ctx context.Context) error {
+	return s.request(ctx, syncRequest{dhcp: true})
+}
+
+func (s *Syncer) request(ctx context.Context, req syncRequest) error {
+	if s == nil {
+		return nil
+	}
+	if s.readOnly {
+		return fmt.Errorf("%w: refusing to sync", ErrReadOnly)
+	}
+	if s.forceFull {
+		req = syncRequest{full: true}
+	}
+	switch s.mode {
+	case SyncModeNone:
+		tflog.Debug(ctx, "Cobbler sync skipped (sync_mode = none)")
+		return nil
+	case SyncModeCoalesced:
+		return s.coalesced(ctx, req)
+	default:
+		return s.run(ctx, req)
+	}
+}
+
+// run performs the syncs described by req, waiting for any sync already in progress.
+// A full sync already covers per-system and DHCP output, so nothing else runs after it.
+func (s *Syncer) run(ctx context.Context, req syncRequest) error {
+	s.running.Lock()
+	defer s.running.Unlock()
+
+	if req.full {
+		tflog.Debug(ctx, "Cobbler: running full sync")
+		return s.backend.SyncFull()
+	}
+	if len(req.systems) > 0 {
+		names := slices.Sorted(maps.Keys(req.systems))
+		tflog.Debug(ctx, "Cobbler: running system sync", map[string]interface{}{"systems": names})
+		if err := s.backend.SyncSystems(names); err != nil {
+			return err
+		}
+	}
+	if req.dhcp {
+		tflog.Debug(ctx, "Cobbler: running DHCP sync")
+		return s.backend.SyncDHCP()
+	}
+	return nil
+}
+
+// coalesced merges req into the pending batch (creating it if necessary), pushes the
+// batch's timer back by the quiet period, and waits for the batch to finish.
+func (s *Syncer) coalesced(ctx context.Context, req syncRequest) error {
+	s.mu.Lock()
+	b := s.pending
+	now := time.Now()
+	if b == nil {
+		b = &syncBatch{
+			deadline: now.Add(s.maxDelay),
+			done:     make(chan struct{}),
+		}
+		b.timer = time.AfterFunc(s.quietPeriod, func() { s.flush(ctx, b) })
+		s.pending = b
+	} else if wait := b.deadline.Sub(now); wait > 0 {
+		if wait > s.quietPeriod {
+			wait = s.quietPeriod
+		}
+		b.timer.Reset(wait)
+	}
+	b.req.merge(req)
+	s.mu.Unlock()
+
+	tflog.Debug(ctx, "Cobbler: waiting for coalesced sync")
+	select {
+	case <-b.done:
+		return b.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush runs the sync for b. Mutations that arrive while the sync is running start a new
+// batch, since the running sync may already have missed them.
+func (s *Syncer) flush(ctx context.Context, b *syncBatch) {
+	s.mu.Lock()
+	if s.pending != b {
+		// A timer Reset raced with the original expiry; the batch was already flushed.
+		s.mu.Unlock()
+		return
+	}
+	s.pending = nil
+	req := b.req
+	s.mu.Unlock()
+
+	b.err = s.run(context.WithoutCancel(ctx), req)
+	close(b.done)
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSyncBackend records every sync call it receives.
+type fakeSyncBackend struct {
+	mu    sync.Mutex
+	calls []string
+	err   error
+}
+
+func (f *fakeSyncBackend) record(call string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, call)
+	return f.err
+}
+
+func (f *fakeSyncBackend) SyncFull() error { return f.record("full") }
+
+func (f *fakeSyncBackend) SyncSystems(names []string) error {
+	return f.record(fmt.Sprintf("systems%v", names))
+}
+
+func (f *fakeSyncBackend) SyncDHCP() error { return f.record("dhcp") }
+
+func (f *fakeSyncBackend) Calls() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.calls)
+}
+
+func newTestSyncer(mode string, forceFull bool, backend syncBackend) *Syncer {
+	return &Syncer{
+		mode:        mode,
+		forceFull:   forceFull,
+		backend:     backend,
+		quietPeriod: 20 * time.Millisecond,
+		maxDelay:    200 * time.Millisecond,
+	}
+}
+
+func assertCalls(t *testing.T, backend *fakeSyncBackend, want ...string) {
+	t.Helper()
+	if got := backend.Calls(); !slices.Equal(got, want) {
+		t.Errorf("expected sync calls %v, got %v", want, got)
+	}
+}
+
+func TestSyncer_perResource(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModePerResource, false, backend)
+
+	for i := 0; i < 3; i++ {
+		if err := s.Sync(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assertCalls(t, backend, "full", "full", "full")
+}
+
+func TestSyncer_none(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModeNone, false, backend)
+
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.SyncSystems(context.Background(), "foo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCalls(t, backend)
+}
+
+func TestSyncer_nil(t *testing.T) {
+	var s *Syncer
+	if err := s.Sync(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSyncer_systemsAndDHCP(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModePerResource, false, backend)
+
+	if err := s.SyncSystems(context.Background(), "foo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.SyncDHCP(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCalls(t, backend, "systems[foo]", "dhcp", "dhcp")
+}
+
+func TestSyncer_systemsStopsOnError(t *testing.T) {
+	backend := &fakeSyncBackend{err: errors.New("boom")}
+	s := newTestSyncer(SyncModePerResource, false, backend)
+
+	if err := s.SyncSystems(context.Background(), "foo"); err == nil {
+		t.Fatal("expected an error")
+	}
+	assertCalls(t, backend, "systems[foo]")
+}
+
+func TestSyncer_forceFull(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModePerResource, true, backend)
+
+	if err := s.SyncSystems(context.Background(), "foo"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := s.SyncDHCP(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertCalls(t, backend, "full", "full")
+}
+
+func TestSyncer_coalesced(t *testing.T) {
+	backend := &fakeSyncBackend{err: errors.New("sync failed")}
+	s := newTestSyncer(SyncModeCoalesced, false, backend)
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.Sync(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	assertCalls(t, backend, "full")
+	for i, err := range errs {
+		if !errors.Is(err, backend.err) {
+			t.Errorf("caller %d: expected shared sync error, got %v", i, err)
+		}
+	}
+}
+
+func TestSyncer_coalescedMergesSystems(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModeCoalesced, false, backend)
+
+	var wg sync.WaitGroup
+	for _, name := range []string{"c", "a", "b", "a"} {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			if err := s.SyncSystems(context.Background(), name); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		}(name)
+	}
+	wg.Wait()
+
+	assertCalls(t, backend, "systems[a b c]", "dhcp")
+}
+
+func TestSyncer_coalescedFullWins(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModeCoalesced, false, backend)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = s.SyncSystems(context.Background(), "foo")
+	}()
+	go func() {
+		defer wg.Done()
+		_ = s.Sync(context.Background())
+	}()
+	wg.Wait()
+
+	assertCalls(t, backend, "full")
+}
+
+func TestSyncer_coalescedSequentialBatches(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModeCoalesced, false, backend)
+
+	for i := 0; i < 2; i++ {
+		if err := s.Sync(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	assertCalls(t, backend, "full", "full")
+}
+
+func TestSyncer_readOnly(t *testing.T) {
+	backend := &fakeSyncBackend{}
+	s := newTestSyncer(SyncModeCoalesced, false, backend)
+	s.readOnly = true
+
+	if err := s.SyncSystems(context.Background(), "foo"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly, got %v", err)
+	}
+	assertCalls(t, backend)
+}
@@ -0,0 +1,205 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultTaskTimeout is how long a background task may run unless a resource says otherwise.
+	DefaultTaskTimeout = 30 * time.Minute
+	// taskPollInterval is the wait between two get_task_status calls.
+	taskPollInterval = 5 * time.Second
+	// taskLogTailLines is how many event log lines a TaskError carries.
+	taskLogTailLines = 20
+)
+
+// Background task states reported by get_task_status.
+const (
+	taskStateComplete = "complete"
+	taskStateFailed   = "failed"
+)
+
+// Power actions accepted by Cobbler's power management.
+const (
+	PowerOn     = "on"
+	PowerOff    = "off"
+	PowerReboot = "reboot"
+)
+
+// PowerActions lists every power action a resource can request.
+var PowerActions = []string{PowerOn, PowerOff, PowerReboot}
+
+// taskBackend starts Cobbler background tasks and reports on them.
+type taskBackend interface {
+	// Start calls the background_* method and returns the task's event id.
+	Start(method string, options map[string]interface{}) (string, error)
+	// Status returns the task's state, e.g. "running", "complete" or "failed".
+	Status(id string) (string, error)
+	// EventLog returns everything the task logged so far.
+	EventLog(id string) (string, error)
+}
+
+// cobblerTaskBackend is the taskBackend backed by the Cobbler XML-RPC API.
+type cobblerTaskBackend struct {
+	client cobbler.Client
+}
+
+func (b *cobblerTaskBackend) Start(method string, options map[string]interface{}) (string, error) {
+	result, err := b.client.Call(method, options, b.client.Token)
+	if err != nil {
+		return "", err
+	}
+	id, ok := result.(string)
+	if !ok {
+		return "", fmt.Errorf("unexpected %s result of type %T", method, result)
+	}
+	return id, nil
+}
+
+func (b *cobblerTaskBackend) Status(id string) (string, error) {
+	result, err := b.client.Call("get_task_status", id)
+	if err != nil {
+		return "", err
+	}
+	// [start time, task name, state, users that read the log]
+	status, ok := result.([]interface{})
+	if !ok || len(status) < 3 {
+		return "", fmt.Errorf("unexpected get_task_status result %v", result)
+	}
+	state, _ := status[2].(string)
+	return state, nil
+}
+
+func (b *cobblerTaskBackend) EventLog(id string) (string, error) {
+	result, err := b.client.Call("get_event_log", id)
+	if err != nil {
+		return "", err
+	}
+	log, _ := result.(string)
+	return log, nil
+}
+
+// TaskError is returned when a background task failed or did not finish in time.
+type TaskError struct {
+	Method string
+	ID     string
+	// Timeout is set when the task was still running after this long. Cobbler cannot cancel
+	// tasks, so it may still finish later.
+	Timeout time.Duration
+	// LogTail holds the last lines of the task's event log.
+	LogTail []string
+}
+
+func (e *TaskError) Error() string {
+	var msg string
+	if e.Timeout > 0 {
+		msg = fmt.Sprintf("cobbler task %s (%s) did not finish within %s", e.ID, e.Method, e.Timeout)
+	} else {
+		msg = fmt.Sprintf("cobbler task %s (%s) failed", e.ID, e.Method)
+	}
+	if len(e.LogTail) == 0 {
+		return msg
+	}
+	return msg + "; last log lines:\n" + strings.Join(e.LogTail, "\n")
+}
+
+// TaskRunner runs Cobbler background tasks (imports, reposyncs, ISO builds, power actions)
+// and waits for them, streaming their event log to the Terraform log.
+type TaskRunner struct {
+	backend      taskBackend
+	pollInterval time.Duration
+}
+
+// NewTaskRunner returns a TaskRunner for client.
+func NewTaskRunner(client cobbler.Client) *TaskRunner {
+	return &TaskRunner{
+		backend:      &cobblerTaskBackend{client: client},
+		pollInterval: taskPollInterval,
+	}
+}
+
+// Run starts the background task method with options and waits up to timeout for it to
+// finish. It returns the task's event id, also when the task failed.
+func (r *TaskRunner) Run(ctx context.Context, method string, options map[string]interface{}, timeout time.Duration) (string, error) {
+	id, err := r.backend.Start(method, options)
+	if err != nil {
+		return "", err
+	}
+	tflog.Info(ctx, "Started Cobbler task", map[string]interface{}{"method": method, "task": id})
+	return id, r.wait(ctx, method, id, timeout)
+}
+
+// Power runs the power action for the named systems through their configured power
+// management (power_type, power_address, ...) and waits up to timeout for it to finish.
+func (r *TaskRunner) Power(ctx context.Context, action string, systems []string, timeout time.Duration) (string, error) {
+	return r.Run(ctx, "background_power_system", map[string]interface{}{
+		"systems": systems,
+		"power":   action,
+	}, timeout)
+}
+
+// wait polls the task until it completed, failed or ran longer than timeout.
+func (r *TaskRunner) wait(ctx context.Context, method, id string, timeout time.Duration) error {
+	if timeout <= 0 {
+		timeout = DefaultTaskTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	logged := 0
+	for {
+		state, err := r.backend.Status(id)
+		if err != nil {
+			return err
+		}
+		lines := r.streamLog(ctx, method, id, &logged)
+
+		switch state {
+		case taskStateComplete:
+			return nil
+		case taskStateFailed:
+			return &TaskError{Method: method, ID: id, LogTail: tail(lines, taskLogTailLines)}
+		}
+		if !time.Now().Before(deadline) {
+			return &TaskError{Method: method, ID: id, Timeout: timeout, LogTail: tail(lines, taskLogTailLines)}
+		}
+
+		timer := time.NewTimer(min(r.pollInterval, time.Until(deadline)))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// streamLog sends the event log lines after the first *logged ones to the Terraform log and
+// returns the whole log. Failing to read the log is not fatal to the task.
+func (r *TaskRunner) streamLog(ctx context.Context, method, id string, logged *int) []string {
+	log, err := r.backend.EventLog(id)
+	if err != nil {
+		tflog.Debug(ctx, "Could not read Cobbler task log", map[string]interface{}{"task": id, "error": err.Error()})
+		return nil
+	}
+	lines := strings.Split(strings.TrimRight(log, "\n"), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		lines = nil
+	}
+	for _, line := range lines[min(*logged, len(lines)):] {
+		tflog.Info(ctx, line, map[string]interface{}{"method": method, "task": id})
+	}
+	*logged = max(*logged, len(lines))
+	return lines
+}
+
+func tail(lines []string, n int) []string {
+	if len(lines) > n {
+		return lines[len(lines)-n:]
+	}
+	return lines
+}
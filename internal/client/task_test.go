@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTaskBackend reports the states in order, repeating the last one, and appends one
+// event log line per status poll.
+type fakeTaskBackend struct {
+	mu       sync.Mutex
+	states   []string
+	polls    int
+	log      []string
+	startErr error
+	method   string
+	options  map[string]interface{}
+}
+
+func (f *fakeTaskBackend) Start(method string, options map[string]interface{}) (string, error) {
+	if f.startErr != nil {
+		return "", f.startErr
+	}
+	f.method, f.options = method, options
+	return "2026-10-16_120000_" + method, nil
+}
+
+func (f *fakeTaskBackend) Status(string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	state := f.states[min(f.polls, len(f.states)-1)]
+	f.polls++
+	f.log = append(f.log, "poll "+state)
+	return state, nil
+}
+
+func (f *fakeTaskBackend) EventLog(string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return strings.Join(f.log, "\n") + "\n", nil
+}
+
+func newTestTaskRunner(backend taskBackend) *TaskRunner {
+	return &TaskRunner{backend: backend, pollInterval: time.Millisecond}
+}
+
+func TestTaskRunner_complete(t *testing.T) {
+	backend := &fakeTaskBackend{states: []string{"running", "running", "complete"}}
+	id, err := newTestTaskRunner(backend).Run(context.Background(), "background_import", nil, time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id != "2026-10-16_120000_background_import" {
+		t.Errorf("unexpected task id %q", id)
+	}
+	if backend.polls != 3 {
+		t.Errorf("expected 3 polls, got %d", backend.polls)
+	}
+}
+
+func TestTaskRunner_failed(t *testing.T) {
+	backend := &fakeTaskBackend{states: []string{"running", "failed"}}
+	id, err := newTestTaskRunner(backend).Run(context.Background(), "background_reposync", nil, time.Second)
+	var te *TaskError
+	if !errors.As(err, &te) {
+		t.Fatalf("expected a *TaskError, got %v", err)
+	}
+	if te.ID != id || te.Timeout != 0 {
+		t.Errorf("unexpected task error %+v", te)
+	}
+	if want := []string{"poll running", "poll failed"}; strings.Join(te.LogTail, ",") != strings.Join(want, ",") {
+		t.Errorf("expected log tail %v, got %v", want, te.LogTail)
+	}
+	if !strings.Contains(err.Error(), "poll failed") {
+		t.Errorf("expected the log tail in the message, got %q", err.Error())
+	}
+}
+
+func TestTaskRunner_timeout(t *testing.T) {
+	backend := &fakeTaskBackend{states: []string{"running"}}
+	_, err := newTestTaskRunner(backend).Run(context.Background(), "background_buildiso", nil, 20*time.Millisecond)
+	var te *TaskError
+	if !errors.As(err, &te) || te.Timeout != 20*time.Millisecond {
+		t.Fatalf("expected a timeout *TaskError, got %v", err)
+	}
+	if len(te.LogTail) > taskLogTailLines {
+		t.Errorf("expected at most %d log lines, got %d", taskLogTailLines, len(te.LogTail))
+	}
+}
+
+func TestTaskRunner_startError(t *testing.T) {
+	backend := &fakeTaskBackend{startErr: errors.New("boom")}
+	if _, err := newTestTaskRunner(backend).Run(context.Background(), "background_import", nil, time.Second); err == nil || err.Error() != "boom" {
+		t.Errorf("expected the start error, got %v", err)
+	}
+}
+
+func TestTaskRunner_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	backend := &fakeTaskBackend{states: []string{"running"}}
+	if _, err := newTestTaskRunner(backend).Run(ctx, "background_import", nil, time.Second); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestTaskRunner_power(t *testing.T) {
+	backend := &fakeTaskBackend{states: []string{"complete"}}
+	if _, err := newTestTaskRunner(backend).Power(context.Background(), PowerReboot, []string{"web01"}, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if backend.method != "background_power_system" {
+		t.Errorf("unexpected method %q", backend.method)
+	}
+	if backend.options["power"] != "reboot" || fmt.Sprint(backend.options["systems"]) != "[web01]" {
+		t.Errorf("unexpected options %v", backend.options)
+	}
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"time"
+)
+
+// TFTP opcodes and limits, see RFC 1350.
+const (
+	tftpOpRRQ   = 1
+	tftpOpData  = 3
+	tftpOpAck   = 4
+	tftpOpError = 5
+
+	tftpErrFileNotFound = 1
+	tftpBlockSize       = 512
+	tftpPort            = "69"
+	// tftpRetries is how often a packet is sent again when the server does not answer.
+	tftpRetries = 3
+)
+
+// tftpTimeout is how long ReadTFTPFile waits for the server's next packet.
+var tftpTimeout = 3 * time.Second
+
+// ReadTFTPFile downloads file from the TFTP server at server, a host with an optional port,
+// e.g. the boot loader configuration Cobbler's sync wrote for a system. A file the server
+// does not have is reported as ErrNotFound.
+func ReadTFTPFile(ctx context.Context, server, file string) ([]byte, error) {
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		server = net.JoinHostPort(server, tftpPort)
+	}
+	addr, err := net.ResolveUDPAddr("udp", server)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.ListenUDP("udp", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	packet := append([]byte{0, tftpOpRRQ}, file...)
+	packet = append(append(packet, 0), "octet\x00"...)
+	// The server answers from a new port, which all later packets go to.
+	var peer *net.UDPAddr
+	dest := addr
+	if _, err := conn.WriteToUDP(packet, dest); err != nil {
+		return nil, err
+	}
+
+	var content []byte
+	block := uint16(1)
+	retries := 0
+	buf := make([]byte, 4+tftpBlockSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		_ = conn.SetReadDeadline(time.Now().Add(tftpTimeout))
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() && retries < tftpRetries {
+				retries++
+				if _, err := conn.WriteToUDP(packet, dest); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, fmt.Errorf("reading %s from TFTP server %s: %w", file, server, err)
+		}
+		if n < 4 || (peer != nil && (!from.IP.Equal(peer.IP) || from.Port != peer.Port)) {
+			continue
+		}
+
+		switch binary.BigEndian.Uint16(buf) {
+		case tftpOpError:
+			msg := string(bytes.TrimRight(buf[4:n], "\x00"))
+			if binary.BigEndian.Uint16(buf[2:]) == tftpErrFileNotFound {
+				return nil, fmt.Errorf("%w: TFTP server %s has no file %s: %s", ErrNotFound, server, file, msg)
+			}
+			return nil, fmt.Errorf("TFTP server %s refused to send %s: %s", server, file, msg)
+		case tftpOpData:
+			if peer == nil {
+				peer, dest = from, from
+			}
+			got := binary.BigEndian.Uint16(buf[2:])
+			if got != block && got != block-1 {
+				continue
+			}
+			// A repeated block means the server missed the acknowledgement.
+			packet = []byte{0, tftpOpAck, byte(got >> 8), byte(got)}
+			if _, err := conn.WriteToUDP(packet, dest); err != nil {
+				return nil, err
+			}
+			if got != block {
+				continue
+			}
+			content = append(content, buf[4:n]...)
+			retries = 0
+			if n-4 < tftpBlockSize {
+				return content, nil
+			}
+			if block == math.MaxUint16 {
+				return nil, fmt.Errorf("%s on TFTP server %s is too large", file, server)
+			}
+			block++
+		}
+	}
+}
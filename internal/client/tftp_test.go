@@ -0,0 +1,102 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newFakeTFTPServer serves files over TFTP from 127.0.0.1 and returns its address. Every
+// transfer sends its first block twice, as a server does when an acknowledgement is lost.
+func newFakeTFTPServer(t *testing.T, files map[string][]byte) string {
+	t.Helper()
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("listening: %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 516)
+		for {
+			n, client, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			if binary.BigEndian.Uint16(buf) != tftpOpRRQ {
+				continue
+			}
+			name, _, _ := strings.Cut(string(buf[2:n]), "\x00")
+			go serveTFTPFile(client, files[name], files[name] != nil)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func serveTFTPFile(client *net.UDPAddr, content []byte, found bool) {
+	// Like a real server, answer from a new port.
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		return
+	}
+	defer func() { _ = conn.Close() }()
+	if !found {
+		_, _ = conn.WriteToUDP(append([]byte{0, tftpOpError, 0, tftpErrFileNotFound}, "File not found\x00"...), client)
+		return
+	}
+
+	ack := make([]byte, 4)
+	for block := 1; ; block++ {
+		end := min(block*tftpBlockSize, len(content))
+		data := append([]byte{0, tftpOpData, byte(block >> 8), byte(block)}, content[(block-1)*tftpBlockSize:end]...)
+		sends := 1
+		if block == 1 {
+			sends = 2
+		}
+		for range sends {
+			if _, err := conn.WriteToUDP(data, client); err != nil {
+				return
+			}
+			_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+			if _, _, err := conn.ReadFromUDP(ack); err != nil || int(binary.BigEndian.Uint16(ack[2:])) != block {
+				return
+			}
+		}
+		if len(data)-4 < tftpBlockSize {
+			return
+		}
+	}
+}
+
+func TestReadTFTPFile(t *testing.T) {
+	files := map[string][]byte{
+		"pxelinux.cfg/01-aa-bb-cc-dd-ee-ff": []byte("DEFAULT linux\n"),
+		"exact":                             bytes.Repeat([]byte("a"), 2*tftpBlockSize),
+		"large":                             bytes.Repeat([]byte("b"), 3*tftpBlockSize+100),
+		"empty":                             {},
+	}
+	server := newFakeTFTPServer(t, files)
+
+	for name, want := range files {
+		t.Run(name, func(t *testing.T) {
+			got, err := ReadTFTPFile(context.Background(), server, name)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("expected %d bytes, got %d", len(want), len(got))
+			}
+		})
+	}
+}
+
+func TestReadTFTPFile_notFound(t *testing.T) {
+	server := newFakeTFTPServer(t, nil)
+	if _, err := ReadTFTPFile(context.Background(), server, "grub/system/aa:bb:cc:dd:ee:ff"); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
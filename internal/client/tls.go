@@ -0,0 +1,79 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// TLSVersions maps the accepted tls_min_version values to their crypto/tls constants.
+var TLSVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// TLSVersionNames lists every accepted tls_min_version value in ascending order.
+var TLSVersionNames = slices.Sorted(maps.Keys(TLSVersions))
+
+// buildTLSConfig assembles the TLS settings for connections to Cobbler. Certificates and keys
+// are passed through readFile, so each can be given as a path or as inline PEM content.
+func (c *Config) buildTLSConfig(readFile func(string) (string, bool, error)) (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		ServerName: c.TLSServerName,
+	}
+
+	if c.TLSMinVersion != "" {
+		version, ok := TLSVersions[c.TLSMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("unsupported minimum TLS version %q", c.TLSMinVersion)
+		}
+		tlsConfig.MinVersion = version
+	}
+
+	if c.CACertFile != "" {
+		caCert, _, err := readFile(c.CACertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA Cert: %s", err)
+		}
+
+		caCertPool := x509.NewCertPool()
+		if c.CACertAppend {
+			if caCertPool, err = x509.SystemCertPool(); err != nil {
+				return nil, fmt.Errorf("error loading system CA certificates: %s", err)
+			}
+		}
+		if !caCertPool.AppendCertsFromPEM([]byte(caCert)) {
+			return nil, errors.New("error reading CA Cert: no valid PEM certificates found")
+		}
+		tlsConfig.RootCAs = caCertPool
+	}
+
+	if c.ClientCertFile != "" || c.ClientKeyFile != "" {
+		if c.ClientCertFile == "" || c.ClientKeyFile == "" {
+			return nil, errors.New("client_cert_file and client_key_file must be set together")
+		}
+		cert, _, err := readFile(c.ClientCertFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client certificate: %s", err)
+		}
+		key, _, err := readFile(c.ClientKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client key: %s", err)
+		}
+		keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %s", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{keyPair}
+	}
+
+	if c.Insecure {
+		tlsConfig.InsecureSkipVerify = true //nolint:gosec
+	}
+	return tlsConfig, nil
+}
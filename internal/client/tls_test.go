@@ -0,0 +1,132 @@
+package client
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+// inline returns its argument as file content, like util.Read does for non-paths.
+func inline(s string) (string, bool, error) {
+	return s, false, nil
+}
+
+// selfSigned returns a PEM certificate and key for a throwaway CA.
+func selfSigned(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cobbler-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshaling key: %v", err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
+
+func TestBuildTLSConfig_defaults(t *testing.T) {
+	c := &Config{}
+	tlsConfig, err := c.buildTLSConfig(inline)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tlsConfig.RootCAs != nil || len(tlsConfig.Certificates) != 0 || tlsConfig.InsecureSkipVerify {
+		t.Errorf("expected an empty TLS configuration, got %+v", tlsConfig)
+	}
+}
+
+func TestBuildTLSConfig_caCert(t *testing.T) {
+	cert, _ := selfSigned(t)
+
+	for _, appendSystem := range []bool{false, true} {
+		c := &Config{CACertFile: cert, CACertAppend: appendSystem}
+		tlsConfig, err := c.buildTLSConfig(inline)
+		if err != nil {
+			t.Fatalf("append=%v: unexpected error: %v", appendSystem, err)
+		}
+		if tlsConfig.RootCAs == nil {
+			t.Fatalf("append=%v: expected a CA pool", appendSystem)
+		}
+	}
+}
+
+func TestBuildTLSConfig_invalidCACert(t *testing.T) {
+	c := &Config{CACertFile: "not a certificate"}
+	if _, err := c.buildTLSConfig(inline); err == nil || !strings.Contains(err.Error(), "no valid PEM certificates") {
+		t.Errorf("expected a PEM error, got %v", err)
+	}
+}
+
+func TestBuildTLSConfig_clientCert(t *testing.T) {
+	cert, key := selfSigned(t)
+
+	c := &Config{ClientCertFile: cert, ClientKeyFile: key}
+	tlsConfig, err := c.buildTLSConfig(inline)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tlsConfig.Certificates) != 1 {
+		t.Errorf("expected 1 client certificate, got %d", len(tlsConfig.Certificates))
+	}
+}
+
+func TestBuildTLSConfig_clientCertErrors(t *testing.T) {
+	cert, key := selfSigned(t)
+	otherCert, _ := selfSigned(t)
+
+	tests := map[string]*Config{
+		"missing key":  {ClientCertFile: cert},
+		"missing cert": {ClientKeyFile: key},
+		"mismatch":     {ClientCertFile: otherCert, ClientKeyFile: key},
+		"invalid":      {ClientCertFile: "cert", ClientKeyFile: "key"},
+	}
+	for name, c := range tests {
+		if _, err := c.buildTLSConfig(inline); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestBuildTLSConfig_serverNameAndVersion(t *testing.T) {
+	c := &Config{TLSServerName: "cobbler.example.com", TLSMinVersion: "1.3"}
+	tlsConfig, err := c.buildTLSConfig(inline)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tlsConfig.ServerName != "cobbler.example.com" {
+		t.Errorf("unexpected server name %q", tlsConfig.ServerName)
+	}
+	if tlsConfig.MinVersion != tls.VersionTLS13 {
+		t.Errorf("expected TLS 1.3, got %x", tlsConfig.MinVersion)
+	}
+
+	c.TLSMinVersion = "1.4"
+	if _, err := c.buildTLSConfig(inline); err == nil {
+		t.Error("expected an error for an unsupported TLS version")
+	}
+}
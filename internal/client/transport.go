@@ -0,0 +1,155 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+// ErrReadOnly is returned for every call that would modify Cobbler while the provider is
+// configured with read_only.
+var ErrReadOnly = errors.New("the Cobbler provider is configured as read-only")
+
+// readOnlyMethodPrefixes lists the XML-RPC methods that never modify Cobbler. Item handles
+// are excluded although they are fetched with get_*_handle, as they only serve edits.
+var readOnlyMethodPrefixes = []string{
+	"check_access",
+	"extended_version",
+	"find_",
+	"generate_",
+	"get_",
+	"has_item",
+	"is_",
+	"last_modified_time",
+	"login",
+	"logout",
+	"ping",
+	"token_check",
+	"version",
+}
+
+// requestTransport applies the provider's per-request HTTP settings to every attempt of a
+// Cobbler XML-RPC call: extra headers (e.g. for an authenticating gateway in front of
+// Cobbler), the path the XML-RPC endpoint is mounted at, and a timeout.
+type requestTransport struct {
+	base    http.RoundTripper
+	headers map[string]string
+	apiPath string
+	timeout time.Duration
+}
+
+func newRequestTransport(base http.RoundTripper, headers map[string]string, apiPath string, timeout time.Duration) http.RoundTripper {
+	if len(headers) == 0 && apiPath == "" && timeout <= 0 {
+		return base
+	}
+	if apiPath != "" && !strings.HasPrefix(apiPath, "/") {
+		apiPath = "/" + apiPath
+	}
+	return &requestTransport{
+		base:    base,
+		headers: headers,
+		apiPath: apiPath,
+		timeout: timeout,
+	}
+}
+
+func (t *requestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.timeout)
+	}
+
+	// RoundTrippers must not modify the request they were given.
+	r := req.Clone(ctx)
+	for name, value := range t.headers {
+		r.Header.Set(name, value)
+	}
+	if t.apiPath != "" {
+		r.URL.Path = t.apiPath
+		r.URL.RawPath = ""
+	}
+
+	resp, err := t.base.RoundTrip(r)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// The timeout covers reading the response, so it may only be released with the body.
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// cancelOnClose releases a request's context once its response body has been closed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// readOnlyTransport rejects every XML-RPC call that could modify Cobbler before it is sent,
+// so a plan-only pipeline cannot change anything even if an apply is started by mistake.
+type readOnlyTransport struct {
+	base http.RoundTripper
+}
+
+func (t *readOnlyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	if method := methodName(body); !isReadOnly(method) {
+		return nil, fmt.Errorf("%w: refusing to call %s", ErrReadOnly, method)
+	}
+
+	r := req.Clone(req.Context())
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+	return t.base.RoundTrip(r)
+}
+
+func isReadOnly(method string) bool {
+	if strings.HasSuffix(method, "_handle") {
+		return false
+	}
+	for _, prefix := range readOnlyMethodPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsReadOnly reports whether err was caused by the provider's read_only setting.
+func IsReadOnly(err error) bool {
+	return errors.Is(err, ErrReadOnly) || strings.Contains(err.Error(), ErrReadOnly.Error())
+}
+
+// WriteGuard rejects resource operations that would modify Cobbler while the provider is
+// configured with read_only. Resources check it first thing in Create, Update and Delete, so
+// operations that never call Cobbler, such as removing a build record from state, are refused
+// as well. A nil WriteGuard allows everything.
+type WriteGuard struct {
+	readOnly bool
+}
+
+// Check reports whether the operation may proceed and adds an error to diags if it may not.
+func (g *WriteGuard) Check(diags *diag.Diagnostics) bool {
+	if g == nil || !g.readOnly {
+		return true
+	}
+	AddClientError(diags, "Cobbler provider is read-only", fmt.Errorf("%w: refusing to apply changes", ErrReadOnly))
+	return false
+}
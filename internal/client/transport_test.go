@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+func TestRequestTransport_headersAndPath(t *testing.T) {
+	var gotPath, gotAuth, gotType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotType = r.Header.Get("Content-Type")
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	transport := newRequestTransport(http.DefaultTransport, map[string]string{"Authorization": "Bearer secret"}, "custom/api", 0)
+	req, err := http.NewRequest(http.MethodPost, server.URL+"/cobbler_api", strings.NewReader("<methodCall/>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "text/xml")
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if gotPath != "/custom/api" {
+		t.Errorf("expected path /custom/api, got %q", gotPath)
+	}
+	if gotAuth != "Bearer secret" {
+		t.Errorf("expected the Authorization header to be sent, got %q", gotAuth)
+	}
+	if gotType != "text/xml" {
+		t.Errorf("expected the original headers to be kept, got Content-Type %q", gotType)
+	}
+	if req.Header.Get("Authorization") != "" || req.URL.Path != "/cobbler_api" {
+		t.Error("expected the original request to stay unmodified")
+	}
+}
+
+func TestRequestTransport_timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	defer server.Close()
+
+	transport := newRequestTransport(http.DefaultTransport, nil, "", 20*time.Millisecond)
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("<methodCall/>"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = transport.RoundTrip(req)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline error, got %v", err)
+	}
+}
+
+func TestRequestTransport_passThrough(t *testing.T) {
+	if transport := newRequestTransport(http.DefaultTransport, nil, "", 0); transport != http.DefaultTransport {
+		t.Error("expected the base transport when no request settings are configured")
+	}
+}
+
+func TestReadOnlyTransport(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, methodName(body))
+		_, _ = io.WriteString(w, okResponse)
+	}))
+	defer server.Close()
+
+	transport := &readOnlyTransport{base: http.DefaultTransport}
+	tests := []struct {
+		method  string
+		allowed bool
+	}{
+		{"get_system", true},
+		{"find_profile", true},
+		{"login", true},
+		{"get_system_handle", false},
+		{"new_system", false},
+		{"modify_system", false},
+		{"save_system", false},
+		{"remove_system", false},
+		{"sync", false},
+		{"background_import", false},
+	}
+
+	for _, tt := range tests {
+		calls = nil
+		_, err := call(t, transport, server.URL, tt.method, "foo")
+		if tt.allowed {
+			if err != nil || len(calls) != 1 {
+				t.Errorf("%s: expected the call to reach Cobbler, got error %v and calls %v", tt.method, err, calls)
+			}
+			continue
+		}
+		if !IsReadOnly(err) {
+			t.Errorf("%s: expected a read-only error, got %v", tt.method, err)
+		}
+		if len(calls) != 0 {
+			t.Errorf("%s: expected the call not to reach Cobbler, got %v", tt.method, calls)
+		}
+	}
+}
+
+func TestWriteGuard(t *testing.T) {
+	var diags diag.Diagnostics
+	if !(*WriteGuard)(nil).Check(&diags) || !(&WriteGuard{}).Check(&diags) || diags.HasError() {
+		t.Fatalf("expected writes to be allowed, got %v", diags)
+	}
+
+	if (&WriteGuard{readOnly: true}).Check(&diags) {
+		t.Error("expected writes to be refused")
+	}
+	if !diags.HasError() || diags[0].Summary() != "Cobbler provider is read-only" {
+		t.Errorf("expected a read-only error, got %v", diags)
+	}
+}
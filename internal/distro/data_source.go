@@ -84,32 +84,40 @@ func (d *DistroDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": schema.SingleNestedAttribute{
 				Description: "Kernel options to use with the kernel.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
 				Description: "Post install kernel options.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -123,6 +131,10 @@ func (d *DistroDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -157,10 +169,16 @@ func (d *DistroDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	distroPtr, err := d.client.GetDistro(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Distro", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Distro", err)
 		return
 	}
 	distro := *distroPtr
+	resolvedPtr, err := d.client.GetDistro(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Distro", err)
+		return
+	}
+	resolved := *resolvedPtr
 
 	data.Name = types.StringValue(distro.Name)
 	data.UID = types.StringValue(distro.Uid)
@@ -173,10 +191,10 @@ func (d *DistroDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.RemoteBootKernel = types.StringValue(distro.RemoteBootKernel)
 	data.OSVersion = types.StringValue(distro.OSVersion)
 	data.SourceTreePath = types.StringValue(distro.SourceTreePath)
-	data.BootLoaders = inherit.StringListFrom(ctx, distro.BootLoaders, &resp.Diagnostics)
-	data.KernelOptions = inherit.StringMapFrom(ctx, distro.KernelOptions, &resp.Diagnostics)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, distro.KernelOptionsPost, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, distro.Owners, &resp.Diagnostics)
+	data.BootLoaders = inherit.StringListFrom(ctx, distro.BootLoaders, resolved.BootLoaders, &resp.Diagnostics)
+	data.KernelOptions = inherit.MapFrom(ctx, distro.KernelOptions, resolved.KernelOptions, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, distro.KernelOptionsPost, resolved.KernelOptionsPost, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, distro.Owners, resolved.Owners, &resp.Diagnostics)
 	templateFiles, d2 := types.MapValueFrom(ctx, types.StringType, distro.TemplateFiles)
 	resp.Diagnostics.Append(d2...)
 	data.TemplateFiles = templateFiles
@@ -2,7 +2,6 @@ package distro
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -12,18 +11,26 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &DistroResource{}
 var _ resource.ResourceWithImportState = &DistroResource{}
+var _ resource.ResourceWithModifyPlan = &DistroResource{}
+var _ resource.ResourceWithUpgradeState = &DistroResource{}
+
+// distroAttributePaths maps Cobbler fields to schema paths for validation errors.
+var distroAttributePaths = clientpkg.AttributePathsFromModel(distroResourceModel{})
 
 type DistroResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
 func NewResource() resource.Resource {
@@ -37,6 +44,7 @@ func (r *DistroResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "`cobbler_distro` manages a distribution within Cobbler.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "A name for the distro.",
@@ -121,7 +129,10 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -136,6 +147,10 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": schema.SingleNestedAttribute{
@@ -143,13 +158,15 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Optional:    true,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Optional: true,
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Optional: true,
@@ -158,6 +175,9 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
@@ -165,13 +185,15 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Optional:    true,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Optional: true,
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Optional: true,
@@ -180,6 +202,9 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -187,7 +212,10 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -202,6 +230,10 @@ func (r *DistroResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -225,9 +257,27 @@ func (r *DistroResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *DistroResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp)
+}
+
+// UpgradeState upgrades state written before the map values became dynamic.
+func (r *DistroResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: inherit.UpgradeMapState("kernel_options", "kernel_options_post"),
+	}
 }
 
 func (r *DistroResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -243,16 +293,29 @@ func (r *DistroResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	newDistro, err := r.client.CreateDistro(distro)
 	if err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error creating Cobbler Distro", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Distro", err, distroAttributePaths)
 		return
 	}
 
-	distroToModel(ctx, *newDistro, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetDistro(newDistro.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Distro", err)
+		return
+	}
+	distroToModel(ctx, *newDistro, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *DistroResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -264,15 +327,20 @@ func (r *DistroResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	distro, err := r.client.GetDistro(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Distro", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Distro", err, distroAttributePaths)
 		return
 	}
 
-	distroToModel(ctx, *distro, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetDistro(distro.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Distro", err)
+		return
+	}
+	distroToModel(ctx, *distro, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -281,6 +349,10 @@ func (r *DistroResource) Read(ctx context.Context, req resource.ReadRequest, res
 }
 
 func (r *DistroResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -295,17 +367,27 @@ func (r *DistroResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Debug(ctx, "Cobbler Distro: Update", map[string]interface{}{"name": distro.Name})
 
 	if err := r.client.UpdateDistro(&distro); err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error updating Cobbler Distro", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Distro", err, distroAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updatedDistro, err := r.client.GetDistro(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Distro after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Distro after update", err, distroAttributePaths)
 		return
 	}
 
-	distroToModel(ctx, *updatedDistro, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetDistro(updatedDistro.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Distro", err)
+		return
+	}
+	distroToModel(ctx, *updatedDistro, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -314,6 +396,10 @@ func (r *DistroResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *DistroResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -323,7 +409,12 @@ func (r *DistroResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Debug(ctx, "Cobbler Distro: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteDistro(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Distro", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Distro", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -345,8 +436,8 @@ func modelToDistro(ctx context.Context, data distroResourceModel, diags *diag.Di
 	distro.OSVersion = data.OSVersion.ValueString()
 	distro.SourceTreePath = data.SourceTreePath.ValueString()
 	distro.BootLoaders = inherit.StringListTo(ctx, data.BootLoaders, diags)
-	distro.KernelOptions = inherit.StringMapTo(ctx, data.KernelOptions, diags)
-	distro.KernelOptionsPost = inherit.StringMapTo(ctx, data.KernelOptionsPost, diags)
+	distro.KernelOptions = inherit.MapTo(ctx, data.KernelOptions, diags)
+	distro.KernelOptionsPost = inherit.MapTo(ctx, data.KernelOptionsPost, diags)
 	distro.Owners = inherit.StringListTo(ctx, data.Owners, diags)
 
 	// ElementsAs fails on null/unknown values; guard to avoid plan-time errors
@@ -360,7 +451,7 @@ func modelToDistro(ctx context.Context, data distroResourceModel, diags *diag.Di
 }
 
 // distroToModel populates a distroResourceModel from a cobbler.Distro.
-func distroToModel(ctx context.Context, distro cobbler.Distro, data *distroResourceModel, diags *diag.Diagnostics) {
+func distroToModel(ctx context.Context, distro cobbler.Distro, resolved cobbler.Distro, data *distroResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(distro.Name)
 	data.UID = types.StringValue(distro.Uid)
 	data.Arch = types.StringValue(distro.Arch)
@@ -372,10 +463,10 @@ func distroToModel(ctx context.Context, distro cobbler.Distro, data *distroResou
 	data.RemoteBootKernel = types.StringValue(distro.RemoteBootKernel)
 	data.OSVersion = types.StringValue(distro.OSVersion)
 	data.SourceTreePath = types.StringValue(distro.SourceTreePath)
-	data.BootLoaders = inherit.StringListFrom(ctx, distro.BootLoaders, diags)
-	data.KernelOptions = inherit.StringMapFrom(ctx, distro.KernelOptions, diags)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, distro.KernelOptionsPost, diags)
-	data.Owners = inherit.StringListFrom(ctx, distro.Owners, diags)
+	data.BootLoaders = inherit.StringListFrom(ctx, distro.BootLoaders, resolved.BootLoaders, diags)
+	data.KernelOptions = inherit.MapFrom(ctx, distro.KernelOptions, resolved.KernelOptions, data.KernelOptions, diags)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, distro.KernelOptionsPost, resolved.KernelOptionsPost, data.KernelOptionsPost, diags)
+	data.Owners = inherit.StringListFrom(ctx, distro.Owners, resolved.Owners, diags)
 	templateFiles, d := types.MapValueFrom(ctx, types.StringType, distro.TemplateFiles)
 	diags.Append(d...)
 	data.TemplateFiles = templateFiles
@@ -121,6 +121,7 @@ func TestAccDistroResource_bootLoadersExplicit(t *testing.T) {
 					resource.TestCheckResourceAttr("cobbler_distro.foo", "name", "foo-resource-distro-boot-loaders"),
 					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.inherited", "false"),
 					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.value.0", "grub"),
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.effective.0", "grub"),
 				),
 			},
 			{
@@ -135,6 +136,7 @@ func TestAccDistroResource_bootLoadersExplicit(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.inherited", "false"),
 					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.value.0", "grub"),
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "boot_loaders.effective.0", "grub"),
 				),
 			},
 			{
@@ -200,3 +202,64 @@ resource "cobbler_distro" "foo" {
   initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
 }
 `
+
+// TestAccDistroResource_kernelOptionsMap sets kernel options from a map(string) variable,
+// which Terraform requires to be read back as a map rather than an object, then switches to
+// an object expression with a flag-only option.
+func TestAccDistroResource_kernelOptionsMap(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDistroResourceKernelOptionsMap,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "kernel_options.inherited", "false"),
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "kernel_options.value.console", "ttyS0"),
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "kernel_options.value.count", "3"),
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "kernel_options.effective.console", "ttyS0"),
+				),
+			},
+			{
+				Config: testAccDistroResourceKernelOptionsObject,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_distro.foo", "kernel_options.value.console", "ttyS0"),
+					resource.TestCheckNoResourceAttr("cobbler_distro.foo", "kernel_options.value.quiet"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDistroResourceKernelOptionsMap = `
+variable "kernel_options" {
+  type    = map(string)
+  default = { console = "ttyS0", count = 3 }
+}
+
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-distro-kernel-options"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+  kernel_options = {
+    value = var.kernel_options
+  }
+}
+`
+
+const testAccDistroResourceKernelOptionsObject = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-distro-kernel-options"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+  kernel_options = {
+    value = { console = "ttyS0", quiet = null }
+  }
+}
+`
@@ -57,7 +57,7 @@ func (d *DistroGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	g, err := d.client.GetDistroGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler DistroGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler DistroGroup", err)
 		return
 	}
 
@@ -2,7 +2,6 @@ package distro_group
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -20,8 +19,12 @@ import (
 var _ resource.Resource = &DistroGroupResource{}
 var _ resource.ResourceWithImportState = &DistroGroupResource{}
 
+// distroGroupAttributePaths maps Cobbler fields to schema paths for validation errors.
+var distroGroupAttributePaths = clientpkg.AttributePathsFromModel(distroGroupResourceModel{})
+
 type DistroGroupResource struct {
 	client cobbler.Client
+	writes *clientpkg.WriteGuard
 }
 
 func NewResource() resource.Resource {
@@ -75,6 +78,7 @@ func (r *DistroGroupResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.writes = cfg.Writes
 }
 
 func modelToGroup(ctx context.Context, data distroGroupResourceModel, diags *diag.Diagnostics) cobbler.DistroGroup {
@@ -120,6 +124,10 @@ func groupToDataSourceModel(ctx context.Context, g cobbler.DistroGroup, data *di
 }
 
 func (r *DistroGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -135,7 +143,7 @@ func (r *DistroGroupResource) Create(ctx context.Context, req resource.CreateReq
 
 	created, err := r.client.CreateDistroGroup(g)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler DistroGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler DistroGroup", err, distroGroupAttributePaths)
 		return
 	}
 
@@ -155,11 +163,11 @@ func (r *DistroGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 	g, err := r.client.GetDistroGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler DistroGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler DistroGroup", err, distroGroupAttributePaths)
 		return
 	}
 
@@ -171,6 +179,10 @@ func (r *DistroGroupResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *DistroGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -185,13 +197,13 @@ func (r *DistroGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	tflog.Debug(ctx, "Cobbler DistroGroup: Update", map[string]interface{}{"name": g.Name})
 
 	if err := r.client.UpdateDistroGroup(&g); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler DistroGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler DistroGroup", err, distroGroupAttributePaths)
 		return
 	}
 
 	updated, err := r.client.GetDistroGroup(g.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler DistroGroup after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler DistroGroup after update", err, distroGroupAttributePaths)
 		return
 	}
 
@@ -203,6 +215,10 @@ func (r *DistroGroupResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *DistroGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data distroGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,7 +228,7 @@ func (r *DistroGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 	tflog.Debug(ctx, "Cobbler DistroGroup: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteDistroGroup(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler DistroGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler DistroGroup", err)
 	}
 }
 
@@ -0,0 +1,418 @@
+package distro_import
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &ImportResource{}
+
+type ImportResource struct {
+	client cobbler.Client
+	syncer *clientpkg.Syncer
+	tasks  *clientpkg.TaskRunner
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &ImportResource{}
+}
+
+func (r *ImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_import"
+}
+
+func (r *ImportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_import` imports an installation tree into Cobbler, like `cobbler import`. " +
+			"The import runs as a Cobbler background task and creates one distro and profile per architecture it finds. " +
+			"Destroying the resource removes the profiles and distros the import created. " +
+			"If the import fails, the items it created so far are kept in the tainted resource and removed before the next import.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name of the import. Cobbler uses it as the prefix of the distro and profile names, e.g. `name-x86_64`. " +
+					"Changing this forces a new resource.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description: "The path of the installation tree on the Cobbler server, e.g. a mounted ISO, or an rsync:// URL. " +
+					"Changing this forces a new resource.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"breed": schema.StringAttribute{
+				Description: "The breed of the operating system, e.g. `redhat` or `ubuntu`. Detected from the tree if not set. " +
+					"Changing this forces a new resource.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"arch": schema.StringAttribute{
+				Description: "Only import the given architecture. Detected from the tree if not set. Changing this forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"os_version": schema.StringAttribute{
+				Description: "The operating system version, e.g. `focal`. Detected from the tree if not set. Changing this forces a new resource.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"autoinstall_file": schema.StringAttribute{
+				Description: "The autoinstall template assigned to the imported profiles. Required for OS versions whose Cobbler signature has no default template. " +
+					"Changing this forces a new resource.",
+				Optional: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The number of seconds to wait for the import task to finish. Defaults to `1800`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(int64(clientpkg.DefaultTaskTimeout / time.Second)),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"task_id": schema.StringAttribute{
+				Description: "The event id of the Cobbler import task.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"distros": schema.ListAttribute{
+				Description: "The names of the distros the import created.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"distro_uids": schema.ListAttribute{
+				Description: "The UIDs of the distros the import created, in the order of `distros`.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"profiles": schema.ListAttribute{
+				Description: "The names of the profiles the import created.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"profile_uids": schema.ListAttribute{
+				Description: "The UIDs of the profiles the import created, in the order of `profiles`. Use these as `cobbler_system.profile`.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ImportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.tasks = cfg.Tasks
+	r.writes = cfg.Writes
+}
+
+func (r *ImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data importResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	distrosBefore, err := r.itemNames("distro")
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error listing Cobbler Distros", err)
+		return
+	}
+	profilesBefore, err := r.itemNames("profile")
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error listing Cobbler Profiles", err)
+		return
+	}
+
+	options := map[string]interface{}{
+		"name": name,
+		"path": data.Path.ValueString(),
+	}
+	for key, value := range map[string]types.String{
+		"breed":            data.Breed,
+		"arch":             data.Arch,
+		"os_version":       data.OSVersion,
+		"autoinstall_file": data.AutoinstallFile,
+	} {
+		if value.ValueString() != "" {
+			options[key] = value.ValueString()
+		}
+	}
+
+	tflog.Debug(ctx, "Cobbler Import: Create", map[string]interface{}{"name": name, "path": data.Path.ValueString()})
+
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
+	taskID, importErr := r.tasks.Run(ctx, "background_import", options, timeout)
+	if taskID == "" {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error importing into Cobbler", importErr)
+		return
+	}
+
+	// A failed import may already have created some items, so they are looked up either way.
+	distrosAfter, err := r.itemNames("distro")
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error listing Cobbler Distros", err)
+		return
+	}
+	profilesAfter, err := r.itemNames("profile")
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error listing Cobbler Profiles", err)
+		return
+	}
+	distros := createdNames(name, distrosBefore, distrosAfter)
+	profiles := createdNames(name, profilesBefore, profilesAfter)
+	if importErr != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error importing into Cobbler", importErr)
+		if len(distros) == 0 && len(profiles) == 0 {
+			return
+		}
+		// Keep the partial import in state. Terraform taints the resource, so the next apply
+		// removes these items before importing again instead of colliding with them.
+		data.TaskID = types.StringValue(taskID)
+		var diags diag.Diagnostics
+		r.setItems(ctx, &data, distros, profiles, &diags)
+		resp.Diagnostics.Append(diags...)
+		if !diags.HasError() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		}
+		return
+	}
+	if len(distros) == 0 {
+		resp.Diagnostics.AddError("Error importing into Cobbler",
+			fmt.Sprintf("Cobbler task %s completed, but created no distro named %q or starting with %q. "+
+				"Check that path contains an installation tree Cobbler recognizes.", taskID, name, name+"-"))
+		return
+	}
+
+	data.TaskID = types.StringValue(taskID)
+	r.setItems(ctx, &data, distros, profiles, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the imported items are tracked in state, so a failed sync does not orphan them.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
+}
+
+func (r *ImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data importResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var distros, profiles []string
+	resp.Diagnostics.Append(data.Distros.ElementsAs(ctx, &distros, false)...)
+	resp.Diagnostics.Append(data.Profiles.ElementsAs(ctx, &profiles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.setItems(ctx, &data, distros, profiles, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Once every imported distro is gone, the import has to run again.
+	if len(data.Distros.Elements()) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Every argument but timeout forces a new import; there is nothing to change in Cobbler.
+	var data importResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ImportResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data importResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var distros, profiles []string
+	resp.Diagnostics.Append(data.Distros.ElementsAs(ctx, &distros, false)...)
+	resp.Diagnostics.Append(data.Profiles.ElementsAs(ctx, &profiles, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler Import: Delete", map[string]interface{}{"distros": distros, "profiles": profiles})
+
+	// Profiles first: Cobbler refuses to remove a distro that still has profiles.
+	for _, profile := range profiles {
+		if err := r.client.DeleteProfile(profile); err != nil && !clientpkg.IsNotFound(err) {
+			clientpkg.AddClientError(&resp.Diagnostics, "Error deleting imported Cobbler Profile", err)
+			return
+		}
+	}
+	for _, distro := range distros {
+		if err := r.client.DeleteDistro(distro); err != nil && !clientpkg.IsNotFound(err) {
+			clientpkg.AddClientError(&resp.Diagnostics, "Error deleting imported Cobbler Distro", err)
+			return
+		}
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
+}
+
+// itemNames returns the names of every Cobbler item of the given type.
+func (r *ImportResource) itemNames(what string) ([]string, error) {
+	result, err := r.client.Call("get_item_names", what)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := result.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected get_item_names result of type %T", result)
+	}
+	names := make([]string, 0, len(items))
+	for _, item := range items {
+		if name, ok := item.(string); ok {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// setItems stores the distros and profiles that still exist, with their UIDs, in data.
+func (r *ImportResource) setItems(ctx context.Context, data *importResourceModel, distros, profiles []string, diags *diag.Diagnostics) {
+	distroNames, distroUIDs := make([]string, 0, len(distros)), make([]string, 0, len(distros))
+	profileNames, profileUIDs := make([]string, 0, len(profiles)), make([]string, 0, len(profiles))
+	for _, name := range distros {
+		distro, err := r.client.GetDistro(name, false, false)
+		if clientpkg.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			clientpkg.AddClientError(diags, "Error reading imported Cobbler Distro", err)
+			return
+		}
+		distroNames = append(distroNames, name)
+		distroUIDs = append(distroUIDs, distro.Uid)
+	}
+	for _, name := range profiles {
+		profile, err := r.client.GetProfile(name, false, false)
+		if clientpkg.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			clientpkg.AddClientError(diags, "Error reading imported Cobbler Profile", err)
+			return
+		}
+		profileNames = append(profileNames, name)
+		profileUIDs = append(profileUIDs, profile.Uid)
+	}
+
+	for _, l := range []struct {
+		dst    *types.List
+		values []string
+	}{
+		{&data.Distros, distroNames},
+		{&data.DistroUIDs, distroUIDs},
+		{&data.Profiles, profileNames},
+		{&data.ProfileUIDs, profileUIDs},
+	} {
+		list, d := types.ListValueFrom(ctx, types.StringType, l.values)
+		diags.Append(d...)
+		*l.dst = list
+	}
+}
+
+// createdNames returns the sorted names in after but not in before that belong to the
+// import called name. Cobbler names imported items name or name-<suffix>, e.g. name-x86_64.
+func createdNames(name string, before, after []string) []string {
+	var created []string
+	for _, item := range after {
+		if (item == name || strings.HasPrefix(item, name+"-")) && !slices.Contains(before, item) {
+			created = append(created, item)
+		}
+	}
+	slices.Sort(created)
+	return created
+}
@@ -0,0 +1,18 @@
+package distro_import
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type importResourceModel struct {
+	Name            types.String `tfsdk:"name"`
+	Path            types.String `tfsdk:"path"`
+	Breed           types.String `tfsdk:"breed"`
+	Arch            types.String `tfsdk:"arch"`
+	OSVersion       types.String `tfsdk:"os_version"`
+	AutoinstallFile types.String `tfsdk:"autoinstall_file"`
+	Timeout         types.Int64  `tfsdk:"timeout"`
+	TaskID          types.String `tfsdk:"task_id"`
+	Distros         types.List   `tfsdk:"distros"`
+	DistroUIDs      types.List   `tfsdk:"distro_uids"`
+	Profiles        types.List   `tfsdk:"profiles"`
+	ProfileUIDs     types.List   `tfsdk:"profile_uids"`
+}
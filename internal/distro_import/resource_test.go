@@ -0,0 +1,37 @@
+package distro_import_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccImportResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccImportResourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_import.foo", "name", "foo-resource-import-basic"),
+					resource.TestCheckResourceAttrSet("cobbler_import.foo", "task_id"),
+					resource.TestCheckResourceAttr("cobbler_import.foo", "distros.#", "1"),
+					resource.TestCheckResourceAttr("cobbler_import.foo", "distros.0", "foo-resource-import-basic-x86_64"),
+					resource.TestCheckResourceAttrSet("cobbler_import.foo", "distro_uids.0"),
+					resource.TestCheckResourceAttrSet("cobbler_import.foo", "profile_uids.0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccImportResourceBasic = `
+resource "cobbler_import" "foo" {
+  name             = "foo-resource-import-basic"
+  path             = "/extracted_iso_image/"
+  breed            = "ubuntu"
+  autoinstall_file = "built-in-sample.seed"
+}
+`
@@ -100,6 +100,9 @@ func (d *ImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.Float64Attribute{
+						Computed: true,
+					},
 				},
 			},
 			"virt_path": schema.StringAttribute{
@@ -116,6 +119,9 @@ func (d *ImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.Int64Attribute{
+						Computed: true,
+					},
 				},
 			},
 			"virt_type": schema.StringAttribute{
@@ -130,26 +136,30 @@ func (d *ImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "Kernel options to use with the kernel.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
 				Description: "Post install kernel options.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -163,6 +173,10 @@ func (d *ImageDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -197,10 +211,16 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	imagePtr, err := d.client.GetImage(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Image", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Image", err)
 		return
 	}
 	image := *imagePtr
+	resolvedPtr, err := d.client.GetImage(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Image", err)
+		return
+	}
+	resolved := *resolvedPtr
 
 	data.Name = types.StringValue(image.Name)
 	data.UID = types.StringValue(image.Uid)
@@ -216,14 +236,14 @@ func (d *ImageDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	data.VirtBridge = types.StringValue(image.VirtBridge)
 	data.VirtCpus = types.Int64Value(int64(image.Virt.Cpus.Data))
 	data.VirtDiskDriver = types.StringValue(image.Virt.DiskDriver)
-	data.VirtFileSize = inherit.Float64From(ctx, image.Virt.FileSize, &resp.Diagnostics)
+	data.VirtFileSize = inherit.Float64From(ctx, image.Virt.FileSize, resolved.Virt.FileSize, &resp.Diagnostics)
 	data.VirtPath = types.StringValue(image.Virt.Path)
-	data.VirtRam = inherit.IntFrom(ctx, image.Virt.Ram, &resp.Diagnostics)
+	data.VirtRam = inherit.IntFrom(ctx, image.Virt.Ram, resolved.Virt.Ram, &resp.Diagnostics)
 	data.VirtType = types.StringValue(image.Virt.Type)
 	data.VirtUEFI = types.BoolValue(image.Virt.UEFI)
-	data.KernelOptions = inherit.StringMapFrom(ctx, image.KernelOptions, &resp.Diagnostics)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, image.KernelOptionsPost, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, image.Owners, &resp.Diagnostics)
+	data.KernelOptions = inherit.MapFrom(ctx, image.KernelOptions, resolved.KernelOptions, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, image.KernelOptionsPost, resolved.KernelOptionsPost, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, image.Owners, resolved.Owners, &resp.Diagnostics)
 
 	bootLoaders, d2 := types.ListValueFrom(ctx, types.StringType, image.BootLoaders)
 	resp.Diagnostics.Append(d2...)
@@ -2,7 +2,6 @@ package image
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -14,18 +13,26 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &ImageResource{}
 var _ resource.ResourceWithImportState = &ImageResource{}
+var _ resource.ResourceWithModifyPlan = &ImageResource{}
+var _ resource.ResourceWithUpgradeState = &ImageResource{}
+
+// imageAttributePaths maps Cobbler fields to schema paths for validation errors.
+var imageAttributePaths = clientpkg.AttributePathsFromModel(imageResourceModel{})
 
 type ImageResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
 func NewResource() resource.Resource {
@@ -39,6 +46,7 @@ func (r *ImageResource) Metadata(_ context.Context, req resource.MetadataRequest
 func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "`cobbler_image` manages an image within Cobbler.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "A name for the image.",
@@ -160,7 +168,10 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Float64Attribute{
@@ -174,6 +185,9 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Float64Attribute{
+						Computed: true,
+					},
 				},
 			},
 			"virt_path": schema.StringAttribute{
@@ -189,7 +203,10 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Int64Attribute{
@@ -203,6 +220,9 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Int64Attribute{
+						Computed: true,
+					},
 				},
 			},
 			"virt_type": schema.StringAttribute{
@@ -226,13 +246,15 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Optional:    true,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Optional: true,
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Optional: true,
@@ -241,6 +263,9 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
@@ -248,13 +273,15 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Optional:    true,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Optional: true,
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{
 						Optional: true,
@@ -263,6 +290,9 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -270,7 +300,10 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -285,6 +318,10 @@ func (r *ImageResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -308,9 +345,27 @@ func (r *ImageResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *ImageResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp)
+}
+
+// UpgradeState upgrades state written before the map values became dynamic.
+func (r *ImageResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: inherit.UpgradeMapState("kernel_options", "kernel_options_post"),
+	}
 }
 
 func (r *ImageResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data imageResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -326,16 +381,29 @@ func (r *ImageResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	newImage, err := r.client.CreateImage(image)
 	if err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error creating Cobbler Image", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Image", err, imageAttributePaths)
 		return
 	}
 
-	imageToModel(ctx, *newImage, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetImage(newImage.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Image", err)
+		return
+	}
+	imageToModel(ctx, *newImage, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -347,15 +415,20 @@ func (r *ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	image, err := r.client.GetImage(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Image", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Image", err, imageAttributePaths)
 		return
 	}
 
-	imageToModel(ctx, *image, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetImage(image.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Image", err)
+		return
+	}
+	imageToModel(ctx, *image, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -364,6 +437,10 @@ func (r *ImageResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *ImageResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data imageResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -378,17 +455,27 @@ func (r *ImageResource) Update(ctx context.Context, req resource.UpdateRequest,
 	tflog.Debug(ctx, "Cobbler Image: Update", map[string]interface{}{"name": image.Name})
 
 	if err := r.client.UpdateImage(&image); err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error updating Cobbler Image", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Image", err, imageAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updatedImage, err := r.client.GetImage(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Image after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Image after update", err, imageAttributePaths)
 		return
 	}
 
-	imageToModel(ctx, *updatedImage, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetImage(updatedImage.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Image", err)
+		return
+	}
+	imageToModel(ctx, *updatedImage, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -397,6 +484,10 @@ func (r *ImageResource) Update(ctx context.Context, req resource.UpdateRequest,
 }
 
 func (r *ImageResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data imageResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -406,7 +497,12 @@ func (r *ImageResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Debug(ctx, "Cobbler Image: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteImage(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Image", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Image", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -450,8 +546,8 @@ func modelToImage(ctx context.Context, data imageResourceModel, diags *diag.Diag
 	image.Virt.Ram = inherit.IntTo(ctx, data.VirtRam, diags)
 	image.Virt.Type = stringOrInherit(data.VirtType)
 	image.Virt.UEFI = data.VirtUEFI.ValueBool()
-	image.KernelOptions = inherit.StringMapTo(ctx, data.KernelOptions, diags)
-	image.KernelOptionsPost = inherit.StringMapTo(ctx, data.KernelOptionsPost, diags)
+	image.KernelOptions = inherit.MapTo(ctx, data.KernelOptions, diags)
+	image.KernelOptionsPost = inherit.MapTo(ctx, data.KernelOptionsPost, diags)
 	image.Owners = inherit.StringListTo(ctx, data.Owners, diags)
 
 	// ElementsAs fails on null/unknown values; guard to avoid plan-time errors
@@ -471,7 +567,7 @@ func modelToImage(ctx context.Context, data imageResourceModel, diags *diag.Diag
 }
 
 // imageToModel populates an imageResourceModel from a cobbler.Image.
-func imageToModel(ctx context.Context, image cobbler.Image, data *imageResourceModel, diags *diag.Diagnostics) {
+func imageToModel(ctx context.Context, image cobbler.Image, resolved cobbler.Image, data *imageResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(image.Name)
 	data.UID = types.StringValue(image.Uid)
 	data.File = types.StringValue(image.File)
@@ -486,14 +582,14 @@ func imageToModel(ctx context.Context, image cobbler.Image, data *imageResourceM
 	data.VirtBridge = types.StringValue(image.VirtBridge)
 	data.VirtCpus = types.Int64Value(int64(image.Virt.Cpus.Data))
 	data.VirtDiskDriver = types.StringValue(image.Virt.DiskDriver)
-	data.VirtFileSize = inherit.Float64From(ctx, image.Virt.FileSize, diags)
+	data.VirtFileSize = inherit.Float64From(ctx, image.Virt.FileSize, resolved.Virt.FileSize, diags)
 	data.VirtPath = types.StringValue(image.Virt.Path)
-	data.VirtRam = inherit.IntFrom(ctx, image.Virt.Ram, diags)
+	data.VirtRam = inherit.IntFrom(ctx, image.Virt.Ram, resolved.Virt.Ram, diags)
 	data.VirtType = types.StringValue(image.Virt.Type)
 	data.VirtUEFI = types.BoolValue(image.Virt.UEFI)
-	data.KernelOptions = inherit.StringMapFrom(ctx, image.KernelOptions, diags)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, image.KernelOptionsPost, diags)
-	data.Owners = inherit.StringListFrom(ctx, image.Owners, diags)
+	data.KernelOptions = inherit.MapFrom(ctx, image.KernelOptions, resolved.KernelOptions, data.KernelOptions, diags)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, image.KernelOptionsPost, resolved.KernelOptionsPost, data.KernelOptionsPost, diags)
+	data.Owners = inherit.StringListFrom(ctx, image.Owners, resolved.Owners, diags)
 
 	bootLoaders, d := types.ListValueFrom(ctx, types.StringType, image.BootLoaders)
 	diags.Append(d...)
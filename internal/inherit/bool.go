@@ -9,18 +9,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// BoolAttrTypes is the attribute type map for a {value bool, inherited bool} nested object.
+// BoolAttrTypes is the attribute type map for a {value bool, inherited bool, effective bool} nested object.
 var BoolAttrTypes = map[string]attr.Type{
 	"value":     types.BoolType,
 	"inherited": types.BoolType,
+	"effective": types.BoolType,
 }
 
 // BoolFrom converts a cobbler Value[bool] to a Terraform types.Object.
-func BoolFrom(_ context.Context, v cobbler.Value[bool], diags *diag.Diagnostics) types.Object {
+// resolved is the same field of the item read with resolved values; it becomes effective.
+func BoolFrom(_ context.Context, v, resolved cobbler.Value[bool], diags *diag.Diagnostics) types.Object {
+	effective := types.BoolNull()
+	if !resolved.IsInherited {
+		effective = types.BoolValue(resolved.Data)
+	}
 	if v.IsInherited {
 		obj, d := types.ObjectValue(BoolAttrTypes, map[string]attr.Value{
 			"value":     types.BoolNull(),
 			"inherited": types.BoolValue(true),
+			"effective": effective,
 		})
 		diags.Append(d...)
 		return obj
@@ -28,6 +35,7 @@ func BoolFrom(_ context.Context, v cobbler.Value[bool], diags *diag.Diagnostics)
 	obj, d := types.ObjectValue(BoolAttrTypes, map[string]attr.Value{
 		"value":     types.BoolValue(v.Data),
 		"inherited": types.BoolValue(false),
+		"effective": effective,
 	})
 	diags.Append(d...)
 	return obj
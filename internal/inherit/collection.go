@@ -0,0 +1,42 @@
+package inherit
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// List and map objects have three states, which Cobbler stores apart and so survive a refresh:
+//
+//   - inherited: inherited = true and a null value, stored as "<<inherit>>";
+//   - explicitly empty: inherited = false and value = [] or {}, stored as an empty list or dict;
+//   - explicit values: inherited = false and a non-empty value.
+//
+// A null value never means empty. An object the configuration leaves unset keeps its state,
+// so a new item inherits.
+
+// collectionValue returns the value of a list or map object, or ok = false if the object
+// inherits. A value without inherited is explicit; inherited = false without a value is an
+// error rather than an empty collection.
+func collectionValue(obj types.Object, diags *diag.Diagnostics) (value attr.Value, ok bool) {
+	if obj.IsNull() || obj.IsUnknown() {
+		return nil, false
+	}
+	attrs := obj.Attributes()
+	value = attrs["value"]
+	known := value != nil && !value.IsNull() && !value.IsUnknown()
+	inherited, isBool := attrs["inherited"].(types.Bool)
+	if !isBool || inherited.IsNull() || inherited.IsUnknown() {
+		return value, known
+	}
+	if inherited.ValueBool() {
+		return nil, false
+	}
+	if !known {
+		diags.AddError("Missing value",
+			"An inheritable list or map sets inherited = false without a value. "+
+				"Set value, [] or {} for an explicitly empty one, or set inherited = true to inherit from the parent.")
+		return nil, false
+	}
+	return value, true
+}
@@ -12,13 +12,19 @@ import (
 var Float64AttrTypes = map[string]attr.Type{
 	"value":     types.Float64Type,
 	"inherited": types.BoolType,
+	"effective": types.Float64Type,
 }
 
-func Float64From(_ context.Context, v cobbler.Value[float64], diags *diag.Diagnostics) types.Object {
+func Float64From(_ context.Context, v, resolved cobbler.Value[float64], diags *diag.Diagnostics) types.Object {
+	effective := types.Float64Null()
+	if !resolved.IsInherited {
+		effective = types.Float64Value(resolved.Data)
+	}
 	if v.IsInherited {
 		obj, d := types.ObjectValue(Float64AttrTypes, map[string]attr.Value{
 			"value":     types.Float64Null(),
 			"inherited": types.BoolValue(true),
+			"effective": effective,
 		})
 		diags.Append(d...)
 		return obj
@@ -26,6 +32,7 @@ func Float64From(_ context.Context, v cobbler.Value[float64], diags *diag.Diagno
 	obj, d := types.ObjectValue(Float64AttrTypes, map[string]attr.Value{
 		"value":     types.Float64Value(v.Data),
 		"inherited": types.BoolValue(false),
+		"effective": effective,
 	})
 	diags.Append(d...)
 	return obj
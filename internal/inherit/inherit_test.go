@@ -2,10 +2,14 @@ package inherit_test
 
 import (
 	"context"
+	"math/big"
+	"reflect"
 	"testing"
 
 	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
 	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -14,8 +18,9 @@ func TestStringFrom_Inherited(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 	v := cobbler.Value[string]{IsInherited: true}
+	resolved := cobbler.Value[string]{Data: "parent"}
 
-	obj := inherit.StringFrom(ctx, v, &diags)
+	obj := inherit.StringFrom(ctx, v, resolved, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -38,6 +43,10 @@ func TestStringFrom_Inherited(t *testing.T) {
 	if !val.IsNull() {
 		t.Error("expected value to be null when inherited")
 	}
+	eff := attrs["effective"].(types.String)
+	if eff.ValueString() != "parent" {
+		t.Errorf("expected effective 'parent', got %q", eff.ValueString())
+	}
 }
 
 func TestStringFrom_Value(t *testing.T) {
@@ -45,7 +54,7 @@ func TestStringFrom_Value(t *testing.T) {
 	var diags diag.Diagnostics
 	v := cobbler.Value[string]{Data: "hello", IsInherited: false}
 
-	obj := inherit.StringFrom(ctx, v, &diags)
+	obj := inherit.StringFrom(ctx, v, v, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -66,7 +75,7 @@ func TestStringRoundTrip(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[string]{Data: "test-value", IsInherited: false}
-	obj := inherit.StringFrom(ctx, original, &diags)
+	obj := inherit.StringFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("StringFrom diagnostics: %v", diags)
 	}
@@ -88,7 +97,7 @@ func TestStringRoundTrip_Inherited(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[string]{IsInherited: true}
-	obj := inherit.StringFrom(ctx, original, &diags)
+	obj := inherit.StringFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("StringFrom diagnostics: %v", diags)
 	}
@@ -120,8 +129,9 @@ func TestBoolFrom_Inherited(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 	v := cobbler.Value[bool]{IsInherited: true}
+	resolved := cobbler.Value[bool]{Data: true}
 
-	obj := inherit.BoolFrom(ctx, v, &diags)
+	obj := inherit.BoolFrom(ctx, v, resolved, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -135,6 +145,10 @@ func TestBoolFrom_Inherited(t *testing.T) {
 	if !val.IsNull() {
 		t.Error("expected value to be null when inherited")
 	}
+	eff := attrs["effective"].(types.Bool)
+	if !eff.ValueBool() {
+		t.Error("expected effective to be true")
+	}
 }
 
 func TestBoolFrom_Value(t *testing.T) {
@@ -142,7 +156,7 @@ func TestBoolFrom_Value(t *testing.T) {
 	var diags diag.Diagnostics
 	v := cobbler.Value[bool]{Data: true, IsInherited: false}
 
-	obj := inherit.BoolFrom(ctx, v, &diags)
+	obj := inherit.BoolFrom(ctx, v, v, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -163,7 +177,7 @@ func TestBoolRoundTrip(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[bool]{Data: true, IsInherited: false}
-	obj := inherit.BoolFrom(ctx, original, &diags)
+	obj := inherit.BoolFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("BoolFrom diagnostics: %v", diags)
 	}
@@ -185,7 +199,7 @@ func TestBoolRoundTrip_Inherited(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[bool]{IsInherited: true}
-	obj := inherit.BoolFrom(ctx, original, &diags)
+	obj := inherit.BoolFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("BoolFrom diagnostics: %v", diags)
 	}
@@ -217,8 +231,9 @@ func TestStringListFrom_Inherited(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 	v := cobbler.Value[[]string]{IsInherited: true}
+	resolved := cobbler.Value[[]string]{Data: []string{"a"}}
 
-	obj := inherit.StringListFrom(ctx, v, &diags)
+	obj := inherit.StringListFrom(ctx, v, resolved, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -232,6 +247,10 @@ func TestStringListFrom_Inherited(t *testing.T) {
 	if !val.IsNull() {
 		t.Error("expected value to be null when inherited")
 	}
+	eff := attrs["effective"].(types.List)
+	if len(eff.Elements()) != 1 {
+		t.Errorf("expected one effective element, got %v", eff)
+	}
 }
 
 func TestStringListFrom_Value(t *testing.T) {
@@ -239,7 +258,7 @@ func TestStringListFrom_Value(t *testing.T) {
 	var diags diag.Diagnostics
 	v := cobbler.Value[[]string]{Data: []string{"a", "b", "c"}, IsInherited: false}
 
-	obj := inherit.StringListFrom(ctx, v, &diags)
+	obj := inherit.StringListFrom(ctx, v, v, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -268,7 +287,7 @@ func TestStringListRoundTrip(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[[]string]{Data: []string{"x", "y"}, IsInherited: false}
-	obj := inherit.StringListFrom(ctx, original, &diags)
+	obj := inherit.StringListFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("StringListFrom diagnostics: %v", diags)
 	}
@@ -290,7 +309,7 @@ func TestStringListRoundTrip_Inherited(t *testing.T) {
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[[]string]{IsInherited: true}
-	obj := inherit.StringListFrom(ctx, original, &diags)
+	obj := inherit.StringListFrom(ctx, original, original, &diags)
 	if diags.HasError() {
 		t.Fatalf("StringListFrom diagnostics: %v", diags)
 	}
@@ -309,7 +328,7 @@ func TestStringListFrom_EmptySlice(t *testing.T) {
 	var diags diag.Diagnostics
 	v := cobbler.Value[[]string]{Data: []string{}, IsInherited: false}
 
-	obj := inherit.StringListFrom(ctx, v, &diags)
+	obj := inherit.StringListFrom(ctx, v, v, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -329,12 +348,13 @@ func TestStringListFrom_EmptySlice(t *testing.T) {
 	}
 }
 
-func TestStringMapFrom_Inherited(t *testing.T) {
+func TestMapFrom_Inherited(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 	v := cobbler.Value[map[string]interface{}]{IsInherited: true}
+	resolved := cobbler.Value[map[string]interface{}]{Data: map[string]interface{}{"key1": "val1"}}
 
-	obj := inherit.StringMapFrom(ctx, v, &diags)
+	obj := inherit.MapFrom(ctx, v, resolved, types.ObjectNull(inherit.MapAttrTypes), &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -344,21 +364,31 @@ func TestStringMapFrom_Inherited(t *testing.T) {
 	if !inherited.ValueBool() {
 		t.Error("expected inherited to be true")
 	}
-	val := attrs["value"].(types.Map)
+	val := attrs["value"].(types.Dynamic)
 	if !val.IsNull() {
 		t.Error("expected value to be null when inherited")
 	}
+	eff := attrs["effective"].(types.Dynamic).UnderlyingValue().(types.Object)
+	if len(eff.Attributes()) != 1 {
+		t.Errorf("expected one effective entry, got %v", eff)
+	}
 }
 
-func TestStringMapFrom_Value(t *testing.T) {
+func TestMapFrom_Value(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 	v := cobbler.Value[map[string]interface{}]{
-		Data:        map[string]interface{}{"key1": "val1", "key2": "val2"},
+		Data: map[string]interface{}{
+			"console": "ttyS0",
+			"quiet":   "~",
+			"count":   int64(3),
+			"debug":   true,
+			"tags":    []interface{}{"a", "b"},
+		},
 		IsInherited: false,
 	}
 
-	obj := inherit.StringMapFrom(ctx, v, &diags)
+	obj := inherit.MapFrom(ctx, v, v, types.ObjectNull(inherit.MapAttrTypes), &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -368,73 +398,195 @@ func TestStringMapFrom_Value(t *testing.T) {
 	if inherited.ValueBool() {
 		t.Error("expected inherited to be false")
 	}
-	mapVal := attrs["value"].(types.Map)
-	if mapVal.IsNull() || mapVal.IsUnknown() {
-		t.Fatal("expected non-null map")
+	val, ok := attrs["value"].(types.Dynamic).UnderlyingValue().(types.Object)
+	if !ok {
+		t.Fatalf("expected an object value, got %v", attrs["value"])
 	}
-	var m map[string]string
-	d := mapVal.ElementsAs(ctx, &m, false)
-	if d.HasError() {
-		t.Fatalf("ElementsAs diagnostics: %v", d)
+	entries := val.Attributes()
+	if !entries["console"].Equal(types.StringValue("ttyS0")) {
+		t.Errorf("unexpected console entry %v", entries["console"])
+	}
+	if !entries["quiet"].IsNull() {
+		t.Errorf("expected the flag-only quiet entry to be null, got %v", entries["quiet"])
 	}
-	if m["key1"] != "val1" || m["key2"] != "val2" {
-		t.Errorf("unexpected map contents: %v", m)
+	if !entries["count"].Equal(types.Int64Value(3)) {
+		t.Errorf("unexpected count entry %v", entries["count"])
+	}
+	if !entries["debug"].Equal(types.BoolValue(true)) {
+		t.Errorf("unexpected debug entry %v", entries["debug"])
+	}
+	if _, ok := entries["tags"].(types.Tuple); !ok {
+		t.Errorf("expected tags to be a tuple, got %v", entries["tags"])
+	}
+}
+
+func TestMapFrom_PriorType(t *testing.T) {
+	ctx := context.Background()
+	v := cobbler.Value[map[string]interface{}]{
+		Data: map[string]interface{}{"console": "ttyS0", "quiet": "~", "count": int64(3)},
+	}
+
+	tests := map[string]struct {
+		prior attr.Value
+		want  attr.Type
+	}{
+		// { console = "ttyS0", quiet = null, count = "3" } from a map(string) variable.
+		"map": {
+			prior: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"console": types.StringValue("ttyS0"),
+				"quiet":   types.StringNull(),
+				"count":   types.StringValue("3"),
+			}),
+			want: types.MapType{ElemType: types.StringType},
+		},
+		// { console = "ttyS0", quiet = null, count = 3 } as an object expression.
+		"object": {
+			prior: types.ObjectValueMust(
+				map[string]attr.Type{"console": types.StringType, "quiet": types.DynamicType, "count": types.NumberType},
+				map[string]attr.Value{
+					"console": types.StringValue("ttyS0"),
+					"quiet":   types.DynamicNull(),
+					"count":   types.NumberValue(big.NewFloat(3)),
+				}),
+			want: types.ObjectType{AttrTypes: map[string]attr.Type{"console": types.StringType, "quiet": types.DynamicType, "count": types.NumberType}},
+		},
+		// A prior value the data no longer fits is replaced with an object.
+		"changed": {
+			prior: types.MapValueMust(types.BoolType, map[string]attr.Value{"debug": types.BoolValue(true)}),
+			want:  types.ObjectType{AttrTypes: map[string]attr.Type{"console": types.StringType, "quiet": types.DynamicType, "count": types.Int64Type}},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			prior := types.ObjectValueMust(inherit.MapAttrTypes, map[string]attr.Value{
+				"value":     types.DynamicValue(tt.prior),
+				"inherited": types.BoolValue(false),
+				"effective": types.DynamicUnknown(),
+			})
+
+			obj := inherit.MapFrom(ctx, v, v, prior, &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			value := obj.Attributes()["value"].(types.Dynamic).UnderlyingValue()
+			if !value.Type(ctx).Equal(tt.want) {
+				t.Errorf("expected a value of type %s, got %s", tt.want, value.Type(ctx))
+			}
+			if name == "map" && !value.Equal(tt.prior) {
+				t.Errorf("expected %v, got %v", tt.prior, value)
+			}
+		})
 	}
 }
 
-func TestStringMapRoundTrip(t *testing.T) {
+func TestMapRoundTrip(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[map[string]interface{}]{
-		Data:        map[string]interface{}{"a": "1", "b": "2"},
+		Data: map[string]interface{}{
+			"console": "ttyS0",
+			"quiet":   "~",
+			"count":   int64(3),
+			"debug":   true,
+			"tags":    []interface{}{"a", "b"},
+		},
 		IsInherited: false,
 	}
-	obj := inherit.StringMapFrom(ctx, original, &diags)
+	obj := inherit.MapFrom(ctx, original, original, types.ObjectNull(inherit.MapAttrTypes), &diags)
 	if diags.HasError() {
-		t.Fatalf("StringMapFrom diagnostics: %v", diags)
+		t.Fatalf("MapFrom diagnostics: %v", diags)
 	}
 
-	result := inherit.StringMapTo(ctx, obj, &diags)
+	result := inherit.MapTo(ctx, obj, &diags)
 	if diags.HasError() {
-		t.Fatalf("StringMapTo diagnostics: %v", diags)
+		t.Fatalf("MapTo diagnostics: %v", diags)
 	}
-	if len(result.Data) != 2 {
-		t.Errorf("expected 2 entries, got %d", len(result.Data))
+	want := map[string]interface{}{
+		"console": "ttyS0",
+		"quiet":   clientpkg.None,
+		"count":   int64(3),
+		"debug":   true,
+		"tags":    []interface{}{"a", "b"},
 	}
-	if result.Data["a"] != "1" || result.Data["b"] != "2" {
-		t.Errorf("unexpected map contents: %v", result.Data)
+	if !reflect.DeepEqual(result.Data, want) {
+		t.Errorf("expected %v, got %v", want, result.Data)
 	}
 	if result.IsInherited {
 		t.Error("expected IsInherited to be false")
 	}
 }
 
-func TestStringMapRoundTrip_Inherited(t *testing.T) {
+func TestMapTo_Config(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	// { console = "ttyS0", quiet = null, count = 3, ratio = 1.5 } as Terraform sends it.
+	value := types.ObjectValueMust(
+		map[string]attr.Type{"console": types.StringType, "quiet": types.DynamicType, "count": types.NumberType, "ratio": types.NumberType},
+		map[string]attr.Value{
+			"console": types.StringValue("ttyS0"),
+			"quiet":   types.DynamicNull(),
+			"count":   types.NumberValue(big.NewFloat(3)),
+			"ratio":   types.NumberValue(big.NewFloat(1.5)),
+		})
+	obj := types.ObjectValueMust(inherit.MapAttrTypes, map[string]attr.Value{
+		"value":     types.DynamicValue(value),
+		"inherited": types.BoolValue(false),
+		"effective": types.DynamicUnknown(),
+	})
+
+	result := inherit.MapTo(ctx, obj, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	want := map[string]interface{}{"console": "ttyS0", "quiet": clientpkg.None, "count": int64(3), "ratio": 1.5}
+	if !reflect.DeepEqual(result.Data, want) {
+		t.Errorf("expected %v, got %v", want, result.Data)
+	}
+}
+
+func TestMapTo_NotAnObject(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	obj := types.ObjectValueMust(inherit.MapAttrTypes, map[string]attr.Value{
+		"value":     types.DynamicValue(types.StringValue("quiet")),
+		"inherited": types.BoolValue(false),
+		"effective": types.DynamicNull(),
+	})
+	inherit.MapTo(ctx, obj, &diags)
+	if !diags.HasError() {
+		t.Error("expected an error for a string value")
+	}
+}
+
+func TestMapRoundTrip_Inherited(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 
 	original := cobbler.Value[map[string]interface{}]{IsInherited: true}
-	obj := inherit.StringMapFrom(ctx, original, &diags)
+	obj := inherit.MapFrom(ctx, original, original, types.ObjectNull(inherit.MapAttrTypes), &diags)
 	if diags.HasError() {
-		t.Fatalf("StringMapFrom diagnostics: %v", diags)
+		t.Fatalf("MapFrom diagnostics: %v", diags)
 	}
 
-	result := inherit.StringMapTo(ctx, obj, &diags)
+	result := inherit.MapTo(ctx, obj, &diags)
 	if diags.HasError() {
-		t.Fatalf("StringMapTo diagnostics: %v", diags)
+		t.Fatalf("MapTo diagnostics: %v", diags)
 	}
 	if !result.IsInherited {
 		t.Error("expected IsInherited to be true")
 	}
 }
 
-func TestStringMapTo_NullObject(t *testing.T) {
+func TestMapTo_NullObject(t *testing.T) {
 	ctx := context.Background()
 	var diags diag.Diagnostics
 
-	obj := types.ObjectNull(inherit.StringMapAttrTypes)
-	result := inherit.StringMapTo(ctx, obj, &diags)
+	obj := types.ObjectNull(inherit.MapAttrTypes)
+	result := inherit.MapTo(ctx, obj, &diags)
 	if diags.HasError() {
 		t.Fatalf("unexpected diagnostics: %v", diags)
 	}
@@ -442,3 +594,61 @@ func TestStringMapTo_NullObject(t *testing.T) {
 		t.Error("expected null object to produce IsInherited=true")
 	}
 }
+
+func TestResolvedScalars(t *testing.T) {
+	if v := inherit.ResolvedString("grub"); v.ValueString() != "grub" {
+		t.Errorf("expected \"grub\", got %v", v)
+	}
+	if v := inherit.ResolvedString("~"); !v.IsNull() {
+		t.Errorf("expected None to be null, got %v", v)
+	}
+	if v := inherit.ResolvedBool(true); !v.ValueBool() {
+		t.Errorf("expected true, got %v", v)
+	}
+	if v := inherit.ResolvedBool("True"); !v.IsNull() {
+		t.Errorf("expected a string to be null, got %v", v)
+	}
+	if v := inherit.ResolvedInt(int64(512)); v.ValueInt64() != 512 {
+		t.Errorf("expected 512, got %v", v)
+	}
+	if v := inherit.ResolvedFloat64(int64(5)); v.ValueFloat64() != 5 {
+		t.Errorf("expected 5, got %v", v)
+	}
+	if v := inherit.ResolvedFloat64(2.5); v.ValueFloat64() != 2.5 {
+		t.Errorf("expected 2.5, got %v", v)
+	}
+}
+
+func TestResolvedStringList(t *testing.T) {
+	ctx := context.Background()
+	var diags diag.Diagnostics
+
+	list := inherit.ResolvedStringList([]interface{}{"grub", "pxe"}, &diags)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	var strs []string
+	diags.Append(list.ElementsAs(ctx, &strs, false)...)
+	if len(strs) != 2 || strs[0] != "grub" || strs[1] != "pxe" {
+		t.Errorf("unexpected list contents: %v", strs)
+	}
+	if list := inherit.ResolvedStringList("~", &diags); !list.IsNull() {
+		t.Errorf("expected None to be null, got %v", list)
+	}
+}
+
+func TestResolvedMap(t *testing.T) {
+	ctx := context.Background()
+
+	m := inherit.ResolvedMap(ctx, map[string]interface{}{"console": "ttyS0", "quiet": "~"})
+	obj, ok := m.UnderlyingValue().(types.Object)
+	if !ok {
+		t.Fatalf("expected an object, got %v", m)
+	}
+	if !obj.Attributes()["console"].Equal(types.StringValue("ttyS0")) || !obj.Attributes()["quiet"].IsNull() {
+		t.Errorf("unexpected map contents: %v", obj)
+	}
+	if m := inherit.ResolvedMap(ctx, nil); !m.IsNull() {
+		t.Errorf("expected a missing map to be null, got %v", m)
+	}
+}
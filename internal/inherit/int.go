@@ -12,13 +12,19 @@ import (
 var IntAttrTypes = map[string]attr.Type{
 	"value":     types.Int64Type,
 	"inherited": types.BoolType,
+	"effective": types.Int64Type,
 }
 
-func IntFrom(_ context.Context, v cobbler.Value[int], diags *diag.Diagnostics) types.Object {
+func IntFrom(_ context.Context, v, resolved cobbler.Value[int], diags *diag.Diagnostics) types.Object {
+	effective := types.Int64Null()
+	if !resolved.IsInherited {
+		effective = types.Int64Value(int64(resolved.Data))
+	}
 	if v.IsInherited {
 		obj, d := types.ObjectValue(IntAttrTypes, map[string]attr.Value{
 			"value":     types.Int64Null(),
 			"inherited": types.BoolValue(true),
+			"effective": effective,
 		})
 		diags.Append(d...)
 		return obj
@@ -26,6 +32,7 @@ func IntFrom(_ context.Context, v cobbler.Value[int], diags *diag.Diagnostics) t
 	obj, d := types.ObjectValue(IntAttrTypes, map[string]attr.Value{
 		"value":     types.Int64Value(int64(v.Data)),
 		"inherited": types.BoolValue(false),
+		"effective": effective,
 	})
 	diags.Append(d...)
 	return obj
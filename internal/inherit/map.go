@@ -0,0 +1,154 @@
+package inherit
+
+import (
+	"context"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// MapAttrTypes is the attribute type map for a {value dynamic, inherited bool, effective dynamic} nested object.
+// value and effective hold a Cobbler dict such as kernel_options or autoinstall_meta as an
+// object, so numbers, booleans and lists keep their types and flag-only kernel options, which
+// Cobbler stores as None, are null.
+var MapAttrTypes = map[string]attr.Type{
+	"value":     types.DynamicType,
+	"inherited": types.BoolType,
+	"effective": types.DynamicType,
+}
+
+// MapFrom converts a cobbler Value[map[string]interface{}] to a Terraform types.Object.
+// resolved is the same field of the item read with resolved values; it becomes effective.
+// prior is the attribute's planned or prior value. Terraform requires the value to keep the
+// type it was configured with, so a value prior holds as a map, e.g. from a map(string)
+// variable, is returned as a map again rather than an object.
+func MapFrom(ctx context.Context, v, resolved cobbler.Value[map[string]interface{}], prior types.Object, diags *diag.Diagnostics) types.Object {
+	effective := types.DynamicNull()
+	if !resolved.IsInherited {
+		effective = mapValue(ctx, resolved.Data, nil)
+	}
+	if v.IsInherited {
+		obj, d := types.ObjectValue(MapAttrTypes, map[string]attr.Value{
+			"value":     types.DynamicNull(),
+			"inherited": types.BoolValue(true),
+			"effective": effective,
+		})
+		diags.Append(d...)
+		return obj
+	}
+	obj, d := types.ObjectValue(MapAttrTypes, map[string]attr.Value{
+		"value":     mapValue(ctx, v.Data, priorType(ctx, prior)),
+		"inherited": types.BoolValue(false),
+		"effective": effective,
+	})
+	diags.Append(d...)
+	return obj
+}
+
+// priorType returns the type of the value prior holds, or nil if it holds none.
+func priorType(ctx context.Context, prior types.Object) attr.Type {
+	if prior.IsNull() || prior.IsUnknown() {
+		return nil
+	}
+	value, ok := prior.Attributes()["value"].(types.Dynamic)
+	if !ok || value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return value.UnderlyingValue().Type(ctx)
+}
+
+// mapValue converts the data of a Cobbler map to a dynamic Terraform value of type t, or to
+// an object if t is nil or the data does not fit it.
+func mapValue(ctx context.Context, data map[string]interface{}, t attr.Type) types.Dynamic {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	if t != nil {
+		if value, err := util.TerraformValueOfType(ctx, fromNone(data), t); err == nil {
+			return types.DynamicValue(value)
+		}
+	}
+	return types.DynamicValue(util.TerraformValue(ctx, fromNone(data)))
+}
+
+// fromNone replaces the "~" Cobbler's API sends for None, e.g. for flag-only kernel options,
+// with nil.
+func fromNone(v interface{}) interface{} {
+	switch v := v.(type) {
+	case string:
+		if v == "~" {
+			return nil
+		}
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = fromNone(e)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = fromNone(e)
+		}
+		return result
+	}
+	return v
+}
+
+// toNone replaces nil with the placeholder the client sends as None, as kolo/xmlrpc would
+// send nil as an empty string.
+func toNone(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return clientpkg.None
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = toNone(e)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = toNone(e)
+		}
+		return result
+	}
+	return v
+}
+
+// MapTo converts a Terraform types.Object back to a cobbler Value[map[string]interface{}].
+// An explicitly empty map stays empty rather than inheriting. Null entries are sent as None,
+// which Cobbler writes as bare flags, e.g. quiet.
+func MapTo(ctx context.Context, obj types.Object, diags *diag.Diagnostics) cobbler.Value[map[string]interface{}] {
+	value, ok := collectionValue(obj, diags)
+	if !ok {
+		return cobbler.Value[map[string]interface{}]{Data: map[string]interface{}{}, IsInherited: true}
+	}
+	val, ok := value.(types.Dynamic)
+	if !ok || val.IsUnderlyingValueNull() || val.IsUnderlyingValueUnknown() {
+		return cobbler.Value[map[string]interface{}]{Data: map[string]interface{}{}, IsInherited: true}
+	}
+	switch val.UnderlyingValue().(type) {
+	case types.Object, types.Map:
+	default:
+		diags.AddError("Invalid map value",
+			"The value must be an object or a map, e.g. { console = \"ttyS0\", quiet = null }, not a "+
+				val.UnderlyingValue().Type(ctx).String()+".")
+		return cobbler.Value[map[string]interface{}]{Data: map[string]interface{}{}}
+	}
+	data, err := util.CobblerValue(ctx, val)
+	if err != nil {
+		diags.AddError("Invalid map value", err.Error())
+		return cobbler.Value[map[string]interface{}]{Data: map[string]interface{}{}}
+	}
+	result, _ := toNone(data).(map[string]interface{})
+	if result == nil {
+		result = map[string]interface{}{}
+	}
+	return cobbler.Value[map[string]interface{}]{Data: result}
+}
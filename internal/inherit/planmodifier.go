@@ -0,0 +1,223 @@
+package inherit
+
+import (
+	"context"
+
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UseStateForUnknown returns the plan modifier for inheritable nested objects. Like
+// objectplanmodifier.UseStateForUnknown it keeps the prior state of an object the
+// configuration leaves unset. The effective value is kept too, unless the object's own
+// value or inherited flag changes, or one of the parents, the root string attributes
+// referencing the items the resource inherits from, changes in the same plan. Parents
+// changed in place keep their references; ModifyPlan covers them.
+//
+// Then an explicit value becomes the planned effective value, except for maps, which
+// Cobbler merges with the parent's. Otherwise the effective value is unknown until apply.
+// A configured value without inherited plans inherited = false.
+func UseStateForUnknown(parents ...path.Path) planmodifier.Object {
+	return useStateForUnknownModifier{parents: parents}
+}
+
+type useStateForUnknownModifier struct {
+	parents []path.Path
+}
+
+func (m useStateForUnknownModifier) Description(_ context.Context) string {
+	return "Once set, the value of this attribute in state will not change. Its effective value changes with the value or the parent."
+}
+
+func (m useStateForUnknownModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownModifier) PlanModifyObject(ctx context.Context, req planmodifier.ObjectRequest, resp *planmodifier.ObjectResponse) {
+	if req.ConfigValue.IsUnknown() || (req.PlanValue.IsUnknown() && req.StateValue.IsNull()) {
+		return
+	}
+
+	planned := req.PlanValue
+	if planned.IsUnknown() {
+		planned = req.StateValue
+	}
+	if planned.IsNull() {
+		return
+	}
+
+	attrTypes := planned.AttributeTypes(ctx)
+	attrs := make(map[string]attr.Value, len(attrTypes))
+	for name, value := range planned.Attributes() {
+		attrs[name] = value
+	}
+	// A value without inherited is explicit.
+	if !req.ConfigValue.IsNull() {
+		config := req.ConfigValue.Attributes()
+		if config["inherited"].IsNull() && !config["value"].IsNull() {
+			attrs["inherited"] = types.BoolValue(false)
+		}
+	}
+
+	switch {
+	case !req.StateValue.IsNull() && !m.ownChanged(req) && !m.parentChanged(ctx, req, &resp.Diagnostics):
+		attrs["effective"] = req.StateValue.Attributes()["effective"]
+	case explicit(attrs):
+		attrs["effective"] = attrs["value"]
+	default:
+		effective, err := unknownValue(ctx, attrTypes["effective"])
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(req.Path, "Error planning inherited value", err.Error())
+			return
+		}
+		attrs["effective"] = effective
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	obj, diags := types.ObjectValue(attrTypes, attrs)
+	resp.Diagnostics.Append(diags...)
+	resp.PlanValue = obj
+}
+
+// ownChanged reports whether the configuration sets value or inherited to something other
+// than the prior state.
+func (m useStateForUnknownModifier) ownChanged(req planmodifier.ObjectRequest) bool {
+	if req.ConfigValue.IsNull() {
+		return false
+	}
+	config, state := req.ConfigValue.Attributes(), req.StateValue.Attributes()
+	for _, name := range []string{"value", "inherited"} {
+		if v := config[name]; !v.IsNull() && !v.Equal(state[name]) {
+			return true
+		}
+	}
+	return false
+}
+
+// parentChanged reports whether a parent reference is unknown or differs from the prior
+// state. The configuration is compared, since parents left unset keep their state.
+func (m useStateForUnknownModifier) parentChanged(ctx context.Context, req planmodifier.ObjectRequest, diags *diag.Diagnostics) bool {
+	for _, p := range m.parents {
+		var config, state types.String
+		diags.Append(req.Config.GetAttribute(ctx, p, &config)...)
+		diags.Append(req.State.GetAttribute(ctx, p, &state)...)
+		if config.IsUnknown() || (!config.IsNull() && !config.Equal(state)) {
+			return true
+		}
+	}
+	return false
+}
+
+// explicit reports whether attrs hold a known explicit value that Cobbler uses as is.
+func explicit(attrs map[string]attr.Value) bool {
+	inherited, ok := attrs["inherited"].(types.Bool)
+	if !ok || inherited.IsUnknown() || inherited.IsNull() || inherited.ValueBool() {
+		return false
+	}
+	value := attrs["value"]
+	if value.IsNull() || value.IsUnknown() {
+		return false
+	}
+	switch value.(type) {
+	case types.Map, types.Dynamic:
+		return false
+	}
+	return true
+}
+
+// ModifyPlan completes the plan of a resource with inheritable nested objects once their
+// plan modifiers ran. Terraform plans the items a resource references before the resource, so
+// if one of the parents recorded in changes plans new effective values, every effective value
+// the resource does not set explicitly is unknown until apply, even though the reference
+// itself stays the same. A resource planning new effective values is recorded in changes by
+// its UID and name in turn, so the change reaches its own children.
+func ModifyPlan(ctx context.Context, changes *clientpkg.PlannedChanges, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, parents ...path.Path) {
+	// Nothing to plan on destroy, and the children of a new item reference an unknown UID.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	parentChanged := false
+	for _, p := range parents {
+		var ref types.String
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &ref)...)
+		if changes.Has(ref.ValueString()) {
+			parentChanged = true
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	changed := false
+	for name, a := range req.Plan.Schema.GetAttributes() {
+		nested, ok := a.(schema.SingleNestedAttribute)
+		if !ok {
+			continue
+		}
+		if _, ok := nested.Attributes["effective"]; !ok {
+			continue
+		}
+
+		var planned, prior types.Object
+		resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root(name), &planned)...)
+		if !req.State.Raw.IsNull() {
+			resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &prior)...)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if parentChanged && !planned.IsNull() && !planned.IsUnknown() {
+			attrs := make(map[string]attr.Value, len(planned.Attributes()))
+			for k, v := range planned.Attributes() {
+				attrs[k] = v
+			}
+			if !explicit(attrs) && !attrs["effective"].IsUnknown() {
+				attrTypes := planned.AttributeTypes(ctx)
+				effective, err := unknownValue(ctx, attrTypes["effective"])
+				if err != nil {
+					resp.Diagnostics.AddAttributeError(path.Root(name), "Error planning inherited value", err.Error())
+					return
+				}
+				attrs["effective"] = effective
+				obj, diags := types.ObjectValue(attrTypes, attrs)
+				resp.Diagnostics.Append(diags...)
+				resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root(name), obj)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+				planned = obj
+			}
+		}
+		if !planned.Equal(prior) {
+			changed = true
+		}
+	}
+	if !changed || req.State.Raw.IsNull() {
+		return
+	}
+
+	for _, name := range []string{"uid", "name"} {
+		if _, ok := req.State.Schema.GetAttributes()[name]; !ok {
+			continue
+		}
+		var ref types.String
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root(name), &ref)...)
+		changes.Add(ref.ValueString())
+	}
+}
+
+// unknownValue returns the unknown value of type t.
+func unknownValue(ctx context.Context, t attr.Type) (attr.Value, error) {
+	return t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), tftypes.UnknownValue))
+}
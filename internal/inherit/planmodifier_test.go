@@ -0,0 +1,230 @@
+package inherit_test
+
+import (
+	"context"
+	"testing"
+
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var testSchema = schema.Schema{
+	Attributes: map[string]schema.Attribute{
+		"uid":     schema.StringAttribute{Computed: true},
+		"profile": schema.StringAttribute{Optional: true},
+		"virt_ram": schema.SingleNestedAttribute{
+			Optional: true,
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"value":     schema.Int64Attribute{Optional: true, Computed: true},
+				"inherited": schema.BoolAttribute{Optional: true, Computed: true},
+				"effective": schema.Int64Attribute{Computed: true},
+			},
+		},
+	},
+}
+
+func virtRam(value types.Int64, inherited types.Bool, effective types.Int64) types.Object {
+	return types.ObjectValueMust(inherit.IntAttrTypes, map[string]attr.Value{
+		"value":     value,
+		"inherited": inherited,
+		"effective": effective,
+	})
+}
+
+func testState(t *testing.T, profile types.String, obj types.Object) tfsdk.State {
+	t.Helper()
+	ctx := context.Background()
+	state := tfsdk.State{Schema: testSchema, Raw: tftypes.NewValue(testSchema.Type().TerraformType(ctx), nil)}
+	if diags := state.SetAttribute(ctx, path.Root("profile"), profile); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if diags := state.SetAttribute(ctx, path.Root("virt_ram"), obj); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return state
+}
+
+// planVirtRam runs the plan modifier for virt_ram with profile as the parent reference.
+func planVirtRam(t *testing.T, configProfile, stateProfile types.String, config, plan, state types.Object) types.Object {
+	t.Helper()
+	req := planmodifier.ObjectRequest{
+		Path:        path.Root("virt_ram"),
+		Config:      tfsdk.Config{Schema: testSchema, Raw: testState(t, configProfile, config).Raw},
+		ConfigValue: config,
+		Plan:        tfsdk.Plan{Schema: testSchema, Raw: testState(t, configProfile, plan).Raw},
+		PlanValue:   plan,
+		State:       testState(t, stateProfile, state),
+		StateValue:  state,
+	}
+	resp := &planmodifier.ObjectResponse{PlanValue: req.PlanValue}
+	inherit.UseStateForUnknown(path.Root("profile")).PlanModifyObject(context.Background(), req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+	return resp.PlanValue
+}
+
+func TestUseStateForUnknown_Unchanged(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	config := types.ObjectNull(inherit.IntAttrTypes)
+
+	got := planVirtRam(t, types.StringValue("p1"), types.StringValue("p1"), config, types.ObjectUnknown(inherit.IntAttrTypes), state)
+	if !got.Equal(state) {
+		t.Errorf("expected the prior state %v, got %v", state, got)
+	}
+}
+
+func TestUseStateForUnknown_ParentChanged(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	config := types.ObjectNull(inherit.IntAttrTypes)
+
+	got := planVirtRam(t, types.StringValue("p2"), types.StringValue("p1"), config, types.ObjectUnknown(inherit.IntAttrTypes), state)
+	attrs := got.Attributes()
+	if !attrs["effective"].IsUnknown() {
+		t.Errorf("expected an unknown effective value, got %v", attrs["effective"])
+	}
+	if !attrs["inherited"].Equal(types.BoolValue(true)) {
+		t.Errorf("expected inherited from state, got %v", attrs["inherited"])
+	}
+}
+
+func TestUseStateForUnknown_ParentUnknown(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	config := types.ObjectNull(inherit.IntAttrTypes)
+
+	got := planVirtRam(t, types.StringUnknown(), types.StringValue("p1"), config, types.ObjectUnknown(inherit.IntAttrTypes), state)
+	if !got.Attributes()["effective"].IsUnknown() {
+		t.Errorf("expected an unknown effective value, got %v", got.Attributes()["effective"])
+	}
+}
+
+func TestUseStateForUnknown_Explicit(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	config := virtRam(types.Int64Value(2048), types.BoolValue(false), types.Int64Null())
+	plan := virtRam(types.Int64Value(2048), types.BoolValue(false), types.Int64Unknown())
+
+	got := planVirtRam(t, types.StringValue("p2"), types.StringValue("p1"), config, plan, state)
+	if eff := got.Attributes()["effective"]; !eff.Equal(types.Int64Value(2048)) {
+		t.Errorf("expected effective 2048, got %v", eff)
+	}
+}
+
+func TestUseStateForUnknown_SwitchToInherited(t *testing.T) {
+	state := virtRam(types.Int64Value(2048), types.BoolValue(false), types.Int64Value(2048))
+	config := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Null())
+	plan := virtRam(types.Int64Unknown(), types.BoolValue(true), types.Int64Unknown())
+
+	got := planVirtRam(t, types.StringValue("p1"), types.StringValue("p1"), config, plan, state)
+	if !got.Attributes()["effective"].IsUnknown() {
+		t.Errorf("expected an unknown effective value, got %v", got.Attributes()["effective"])
+	}
+}
+
+func TestUseStateForUnknown_ValueWithoutInherited(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	config := virtRam(types.Int64Value(2048), types.BoolNull(), types.Int64Null())
+	plan := virtRam(types.Int64Value(2048), types.BoolValue(true), types.Int64Unknown())
+
+	got := planVirtRam(t, types.StringValue("p1"), types.StringValue("p1"), config, plan, state)
+	if inherited := got.Attributes()["inherited"]; !inherited.Equal(types.BoolValue(false)) {
+		t.Errorf("expected inherited = false, got %v", inherited)
+	}
+	if eff := got.Attributes()["effective"]; !eff.Equal(types.Int64Value(2048)) {
+		t.Errorf("expected effective 2048, got %v", eff)
+	}
+}
+
+// modifyPlan runs ModifyPlan for an item with UID u1 and profile as the parent reference, and
+// returns the planned virt_ram.
+func modifyPlan(t *testing.T, changes *clientpkg.PlannedChanges, profile types.String, plan, state types.Object) types.Object {
+	t.Helper()
+	ctx := context.Background()
+	priorState := testState(t, profile, state)
+	if diags := priorState.SetAttribute(ctx, path.Root("uid"), types.StringValue("u1")); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	req := resource.ModifyPlanRequest{
+		Config: tfsdk.Config{Schema: testSchema, Raw: testState(t, profile, plan).Raw},
+		Plan:   tfsdk.Plan{Schema: testSchema, Raw: priorState.Raw},
+		State:  priorState,
+	}
+	if diags := req.Plan.SetAttribute(ctx, path.Root("virt_ram"), plan); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	resp := &resource.ModifyPlanResponse{Plan: req.Plan}
+	inherit.ModifyPlan(ctx, changes, req, resp, path.Root("profile"))
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var got types.Object
+	if diags := resp.Plan.GetAttribute(ctx, path.Root("virt_ram"), &got); diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return got
+}
+
+func TestModifyPlan_ParentUnchanged(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	changes := &clientpkg.PlannedChanges{}
+	changes.Add("p2")
+
+	got := modifyPlan(t, changes, types.StringValue("p1"), state, state)
+	if !got.Equal(state) {
+		t.Errorf("expected the prior state %v, got %v", state, got)
+	}
+	if changes.Has("u1") {
+		t.Error("expected the item not to be recorded as changed")
+	}
+}
+
+func TestModifyPlan_ParentChanged(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	changes := &clientpkg.PlannedChanges{}
+	changes.Add("p1")
+
+	got := modifyPlan(t, changes, types.StringValue("p1"), state, state)
+	if !got.Attributes()["effective"].IsUnknown() {
+		t.Errorf("expected an unknown effective value, got %v", got.Attributes()["effective"])
+	}
+	if !changes.Has("u1") {
+		t.Error("expected the item to be recorded as changed for its children")
+	}
+}
+
+func TestModifyPlan_ParentChangedExplicit(t *testing.T) {
+	state := virtRam(types.Int64Value(2048), types.BoolValue(false), types.Int64Value(2048))
+	changes := &clientpkg.PlannedChanges{}
+	changes.Add("p1")
+
+	got := modifyPlan(t, changes, types.StringValue("p1"), state, state)
+	if !got.Equal(state) {
+		t.Errorf("expected the prior state %v, got %v", state, got)
+	}
+	if changes.Has("u1") {
+		t.Error("expected the item not to be recorded as changed")
+	}
+}
+
+func TestModifyPlan_OwnChange(t *testing.T) {
+	state := virtRam(types.Int64Null(), types.BoolValue(true), types.Int64Value(512))
+	plan := virtRam(types.Int64Value(2048), types.BoolValue(false), types.Int64Value(2048))
+	changes := &clientpkg.PlannedChanges{}
+
+	got := modifyPlan(t, changes, types.StringValue("p1"), plan, state)
+	if !got.Equal(plan) {
+		t.Errorf("expected the plan %v, got %v", plan, got)
+	}
+	if !changes.Has("u1") {
+		t.Error("expected the item to be recorded as changed for its children")
+	}
+}
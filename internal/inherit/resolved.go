@@ -0,0 +1,77 @@
+package inherit
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The Resolved* functions convert a value from Cobbler's blended or resolved item data, where
+// inheritance has already been applied, to a plain Terraform value. Lists and maps use the
+// same conversions as the {value, inherited} objects. Values of an unexpected type,
+// and None, which Cobbler sends as "~", become null.
+
+// ResolvedString converts a resolved string.
+func ResolvedString(v interface{}) types.String {
+	if s, ok := v.(string); ok && s != "~" {
+		return types.StringValue(s)
+	}
+	return types.StringNull()
+}
+
+// ResolvedBool converts a resolved bool.
+func ResolvedBool(v interface{}) types.Bool {
+	if b, ok := v.(bool); ok {
+		return types.BoolValue(b)
+	}
+	return types.BoolNull()
+}
+
+// ResolvedInt converts a resolved integer.
+func ResolvedInt(v interface{}) types.Int64 {
+	switch v := v.(type) {
+	case int64:
+		return types.Int64Value(v)
+	case int:
+		return types.Int64Value(int64(v))
+	}
+	return types.Int64Null()
+}
+
+// ResolvedFloat64 converts a resolved number. Cobbler sends whole numbers as integers.
+func ResolvedFloat64(v interface{}) types.Float64 {
+	switch v := v.(type) {
+	case float64:
+		return types.Float64Value(v)
+	case int64:
+		return types.Float64Value(float64(v))
+	case int:
+		return types.Float64Value(float64(v))
+	}
+	return types.Float64Null()
+}
+
+// ResolvedStringList converts a resolved list of strings.
+func ResolvedStringList(v interface{}, diags *diag.Diagnostics) types.List {
+	items, ok := v.([]interface{})
+	if !ok {
+		return types.ListNull(types.StringType)
+	}
+	data := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			data = append(data, s)
+		}
+	}
+	return stringListValue(data, diags)
+}
+
+// ResolvedMap converts a resolved map to a dynamic object.
+func ResolvedMap(ctx context.Context, v interface{}) types.Dynamic {
+	data, ok := v.(map[string]interface{})
+	if !ok {
+		return types.DynamicNull()
+	}
+	return mapValue(ctx, data, nil)
+}
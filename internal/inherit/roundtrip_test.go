@@ -0,0 +1,346 @@
+package inherit_test
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/cobbler/terraform-provider-cobbler/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/kolo/xmlrpc"
+)
+
+const inheritMarker = "<<inherit>>"
+
+type methodCall struct {
+	Name   string `xml:"methodName"`
+	Params []struct {
+		Value string `xml:",innerxml"`
+	} `xml:"params>param"`
+}
+
+// fakeCobbler is an XML-RPC server that stores the fields of one item the way Cobbler
+// does: "<<inherit>>", or the list or dict as sent. Like Python, it reads <nil/> as None and
+// an empty <value/> as an empty string. Resolved reads replace "<<inherit>>" with the
+// parent's value and merge dicts into the parent's, and None is sent as "~".
+type fakeCobbler struct {
+	mu     sync.Mutex
+	fields map[string]interface{}
+	parent map[string]interface{}
+}
+
+// newFakeCobbler starts a fakeCobbler and returns a client that talks to it through the
+// provider's transport.
+func newFakeCobbler(t *testing.T, parent map[string]interface{}) cobbler.Client {
+	t.Helper()
+	f := &fakeCobbler{fields: map[string]interface{}{}, parent: parent}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+	cfg := &clientpkg.Config{URL: server.URL}
+	if err := cfg.LoadAndValidate(util.Read); err != nil {
+		t.Fatalf("configuring client: %v", err)
+	}
+	return cfg.CobblerClient
+}
+
+func (f *fakeCobbler) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var req methodCall
+	if err := xml.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	args := make([]interface{}, len(req.Params))
+	for i, p := range req.Params {
+		// kolo/xmlrpc would decode an empty <value/> as nil rather than as Python's "".
+		raw := strings.ReplaceAll(p.Value, "<value/>", "<value><string></string></value>")
+		if err := xmlrpc.Response(wrapResponse(raw)).Unmarshal(&args[i]); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result interface{} = true
+	switch req.Name {
+	case "modify_item":
+		f.fields[args[0].(string)] = args[1]
+	case "get_item":
+		field, resolved := args[0].(string), args[1].(bool)
+		result = f.fields[field]
+		if resolved {
+			result = resolve(result, f.parent[field])
+		}
+	default:
+		http.Error(w, "unknown method "+req.Name, http.StatusBadRequest)
+		return
+	}
+	encoded, err := xmlrpc.EncodeMethodCall("", noneToTilde(result))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var resp methodCall
+	if err := xml.Unmarshal(encoded, &resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = io.WriteString(w, string(wrapResponse(resp.Params[0].Value)))
+}
+
+// noneToTilde replaces None with the "~" Cobbler's API sends for it.
+func noneToTilde(v interface{}) interface{} {
+	switch v := v.(type) {
+	case nil:
+		return "~"
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, e := range v {
+			result[i] = noneToTilde(e)
+		}
+		return result
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for k, e := range v {
+			result[k] = noneToTilde(e)
+		}
+		return result
+	}
+	return v
+}
+
+// resolve returns the value Cobbler reports for v in a resolved read.
+func resolve(v, parent interface{}) interface{} {
+	if v == inheritMarker {
+		return parent
+	}
+	dict, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+	merged := map[string]interface{}{}
+	if parentDict, ok := parent.(map[string]interface{}); ok {
+		for k, e := range parentDict {
+			merged[k] = e
+		}
+	}
+	for k, e := range dict {
+		merged[k] = e
+	}
+	return merged
+}
+
+func wrapResponse(value string) xmlrpc.Response {
+	return xmlrpc.Response("<?xml version='1.0'?><methodResponse><params><param>" + value + "</param></params></methodResponse>")
+}
+
+// rpc performs an XML-RPC call against the fake server and returns the decoded result.
+func rpc(t *testing.T, client cobbler.Client, method string, args ...interface{}) interface{} {
+	t.Helper()
+	result, err := client.Call(method, args...)
+	if err != nil {
+		t.Fatalf("calling %s: %v", method, err)
+	}
+	return result
+}
+
+// wire converts a cobbler Value to what is sent to Cobbler.
+func wire[T any](v cobbler.Value[T]) interface{} {
+	if v.IsInherited {
+		return inheritMarker
+	}
+	return v.Data
+}
+
+func readList(t *testing.T, client cobbler.Client, resolved bool) cobbler.Value[[]string] {
+	t.Helper()
+	raw := rpc(t, client, "get_item", "owners", resolved)
+	if raw == inheritMarker {
+		return cobbler.Value[[]string]{IsInherited: true}
+	}
+	items, ok := raw.([]interface{})
+	if !ok && raw != nil {
+		t.Fatalf("expected a list, got %#v", raw)
+	}
+	data := []string{}
+	for _, item := range items {
+		data = append(data, item.(string))
+	}
+	return cobbler.Value[[]string]{Data: data}
+}
+
+func readMap(t *testing.T, client cobbler.Client, resolved bool) cobbler.Value[map[string]interface{}] {
+	t.Helper()
+	raw := rpc(t, client, "get_item", "kernel_options", resolved)
+	if raw == inheritMarker {
+		return cobbler.Value[map[string]interface{}]{IsInherited: true}
+	}
+	data, ok := raw.(map[string]interface{})
+	if !ok && raw != nil {
+		t.Fatalf("expected a dict, got %#v", raw)
+	}
+	return cobbler.Value[map[string]interface{}]{Data: data}
+}
+
+func stringList(t *testing.T, elems ...string) types.List {
+	t.Helper()
+	// A nil slice would convert to a null list rather than an empty one.
+	if elems == nil {
+		elems = []string{}
+	}
+	list, diags := types.ListValueFrom(context.Background(), types.StringType, elems)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	return list
+}
+
+func TestStringList_ThreeStates(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		name          string
+		value         types.List
+		inherited     types.Bool
+		wantInherited bool
+		wantValue     []string
+		wantEffective []string
+	}{
+		{"inherited", types.ListNull(types.StringType), types.BoolValue(true), true, nil, []string{"admin"}},
+		{"explicit empty", stringList(t), types.BoolNull(), false, []string{}, []string{}},
+		{"explicit empty and not inherited", stringList(t), types.BoolValue(false), false, []string{}, []string{}},
+		{"explicit values", stringList(t, "a", "b"), types.BoolNull(), false, []string{"a", "b"}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeCobbler(t, map[string]interface{}{"owners": []interface{}{"admin"}})
+			var diags diag.Diagnostics
+			config := types.ObjectValueMust(inherit.StringListAttrTypes, map[string]attr.Value{
+				"value":     tt.value,
+				"inherited": tt.inherited,
+				"effective": types.ListUnknown(types.StringType),
+			})
+
+			sent := inherit.StringListTo(ctx, config, &diags)
+			rpc(t, client, "modify_item", "owners", wire(sent))
+			obj := inherit.StringListFrom(ctx, readList(t, client, false), readList(t, client, true), &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			attrs := obj.Attributes()
+			if !attrs["inherited"].Equal(types.BoolValue(tt.wantInherited)) {
+				t.Errorf("expected inherited = %v, got %v", tt.wantInherited, attrs["inherited"])
+			}
+			if tt.wantValue == nil {
+				if !attrs["value"].IsNull() {
+					t.Errorf("expected a null value, got %v", attrs["value"])
+				}
+			} else if !attrs["value"].Equal(stringList(t, tt.wantValue...)) {
+				t.Errorf("expected value %v, got %v", tt.wantValue, attrs["value"])
+			}
+			if !attrs["effective"].Equal(stringList(t, tt.wantEffective...)) {
+				t.Errorf("expected effective %v, got %v", tt.wantEffective, attrs["effective"])
+			}
+
+			// The refreshed object is sent unchanged by the next apply.
+			if again := inherit.StringListTo(ctx, obj, &diags); !reflect.DeepEqual(again, sent) {
+				t.Errorf("expected %#v after a refresh, got %#v", sent, again)
+			}
+		})
+	}
+}
+
+func TestMap_ThreeStates(t *testing.T) {
+	ctx := context.Background()
+	parent := map[string]interface{}{"console": "ttyS0"}
+	tests := []struct {
+		name          string
+		value         types.Dynamic
+		inherited     types.Bool
+		wantInherited bool
+		wantValue     interface{}
+		wantEffective interface{}
+	}{
+		{"inherited", types.DynamicNull(), types.BoolValue(true),
+			true, nil, map[string]interface{}{"console": "ttyS0"}},
+		{"explicit empty", types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})), types.BoolNull(),
+			false, map[string]interface{}{}, map[string]interface{}{"console": "ttyS0"}},
+		{"explicit values", types.DynamicValue(types.ObjectValueMust(
+			map[string]attr.Type{"console": types.StringType, "quiet": types.DynamicType},
+			map[string]attr.Value{"console": types.StringValue("tty0"), "quiet": types.DynamicNull()},
+		)), types.BoolValue(false),
+			false, map[string]interface{}{"console": "tty0", "quiet": nil}, map[string]interface{}{"console": "tty0", "quiet": nil}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newFakeCobbler(t, map[string]interface{}{"kernel_options": parent})
+			var diags diag.Diagnostics
+			config := types.ObjectValueMust(inherit.MapAttrTypes, map[string]attr.Value{
+				"value":     tt.value,
+				"inherited": tt.inherited,
+				"effective": types.DynamicUnknown(),
+			})
+
+			sent := inherit.MapTo(ctx, config, &diags)
+			rpc(t, client, "modify_item", "kernel_options", wire(sent))
+			obj := inherit.MapFrom(ctx, readMap(t, client, false), readMap(t, client, true), types.ObjectNull(inherit.MapAttrTypes), &diags)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			attrs := obj.Attributes()
+			if !attrs["inherited"].Equal(types.BoolValue(tt.wantInherited)) {
+				t.Errorf("expected inherited = %v, got %v", tt.wantInherited, attrs["inherited"])
+			}
+			for name, want := range map[string]interface{}{"value": tt.wantValue, "effective": tt.wantEffective} {
+				got, err := util.CobblerValue(ctx, attrs[name])
+				if err != nil {
+					t.Fatalf("converting %s: %v", name, err)
+				}
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("expected %s %#v, got %#v", name, want, got)
+				}
+			}
+
+			if again := inherit.MapTo(ctx, obj, &diags); !reflect.DeepEqual(again, sent) {
+				t.Errorf("expected %#v after a refresh, got %#v", sent, again)
+			}
+		})
+	}
+}
+
+func TestCollectionTo_MissingValue(t *testing.T) {
+	ctx := context.Background()
+
+	var diags diag.Diagnostics
+	inherit.StringListTo(ctx, types.ObjectValueMust(inherit.StringListAttrTypes, map[string]attr.Value{
+		"value":     types.ListNull(types.StringType),
+		"inherited": types.BoolValue(false),
+		"effective": types.ListUnknown(types.StringType),
+	}), &diags)
+	if !diags.HasError() || diags[0].Summary() != "Missing value" {
+		t.Errorf("expected a missing value error for the list, got %v", diags)
+	}
+
+	diags = nil
+	inherit.MapTo(ctx, types.ObjectValueMust(inherit.MapAttrTypes, map[string]attr.Value{
+		"value":     types.DynamicNull(),
+		"inherited": types.BoolValue(false),
+		"effective": types.DynamicUnknown(),
+	}), &diags)
+	if !diags.HasError() || diags[0].Summary() != "Missing value" {
+		t.Errorf("expected a missing value error for the map, got %v", diags)
+	}
+}
@@ -9,18 +9,25 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// StringAttrTypes is the attribute type map for a {value string, inherited bool} nested object.
+// StringAttrTypes is the attribute type map for a {value string, inherited bool, effective string} nested object.
 var StringAttrTypes = map[string]attr.Type{
 	"value":     types.StringType,
 	"inherited": types.BoolType,
+	"effective": types.StringType,
 }
 
 // StringFrom converts a cobbler Value[string] to a Terraform types.Object.
-func StringFrom(_ context.Context, v cobbler.Value[string], diags *diag.Diagnostics) types.Object {
+// resolved is the same field of the item read with resolved values; it becomes effective.
+func StringFrom(_ context.Context, v, resolved cobbler.Value[string], diags *diag.Diagnostics) types.Object {
+	effective := types.StringNull()
+	if !resolved.IsInherited {
+		effective = types.StringValue(resolved.Data)
+	}
 	if v.IsInherited {
 		obj, d := types.ObjectValue(StringAttrTypes, map[string]attr.Value{
 			"value":     types.StringNull(),
 			"inherited": types.BoolValue(true),
+			"effective": effective,
 		})
 		diags.Append(d...)
 		return obj
@@ -28,6 +35,7 @@ func StringFrom(_ context.Context, v cobbler.Value[string], diags *diag.Diagnost
 	obj, d := types.ObjectValue(StringAttrTypes, map[string]attr.Value{
 		"value":     types.StringValue(v.Data),
 		"inherited": types.BoolValue(false),
+		"effective": effective,
 	})
 	diags.Append(d...)
 	return obj
@@ -9,58 +9,61 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-// StringListAttrTypes is the attribute type map for a {value []string, inherited bool} nested object.
+// StringListAttrTypes is the attribute type map for a {value []string, inherited bool, effective []string} nested object.
 var StringListAttrTypes = map[string]attr.Type{
 	"value":     types.ListType{ElemType: types.StringType},
 	"inherited": types.BoolType,
+	"effective": types.ListType{ElemType: types.StringType},
 }
 
 // StringListFrom converts a cobbler Value[[]string] to a Terraform types.Object.
-func StringListFrom(ctx context.Context, v cobbler.Value[[]string], diags *diag.Diagnostics) types.Object {
+// resolved is the same field of the item read with resolved values; it becomes effective.
+func StringListFrom(_ context.Context, v, resolved cobbler.Value[[]string], diags *diag.Diagnostics) types.Object {
+	effective := types.ListNull(types.StringType)
+	if !resolved.IsInherited {
+		effective = stringListValue(resolved.Data, diags)
+	}
 	if v.IsInherited {
 		obj, d := types.ObjectValue(StringListAttrTypes, map[string]attr.Value{
 			"value":     types.ListNull(types.StringType),
 			"inherited": types.BoolValue(true),
+			"effective": effective,
 		})
 		diags.Append(d...)
 		return obj
 	}
-	elems := make([]attr.Value, len(v.Data))
-	for i, s := range v.Data {
-		elems[i] = types.StringValue(s)
-	}
-	listVal, d := types.ListValue(types.StringType, elems)
-	diags.Append(d...)
 	obj, d := types.ObjectValue(StringListAttrTypes, map[string]attr.Value{
-		"value":     listVal,
+		"value":     stringListValue(v.Data, diags),
 		"inherited": types.BoolValue(false),
+		"effective": effective,
 	})
 	diags.Append(d...)
 	return obj
 }
 
-// StringListTo converts a Terraform types.Object back to a cobbler Value[[]string].
-func StringListTo(ctx context.Context, obj types.Object, diags *diag.Diagnostics) cobbler.Value[[]string] {
-	if obj.IsNull() || obj.IsUnknown() {
-		return cobbler.Value[[]string]{IsInherited: true}
+// stringListValue converts the data of a Cobbler list to a Terraform list of strings.
+func stringListValue(data []string, diags *diag.Diagnostics) types.List {
+	elems := make([]attr.Value, len(data))
+	for i, s := range data {
+		elems[i] = types.StringValue(s)
 	}
-	attrs := obj.Attributes()
-	inherited, ok := attrs["inherited"].(types.Bool)
-	if !ok || inherited.IsNull() || inherited.IsUnknown() {
+	listVal, d := types.ListValue(types.StringType, elems)
+	diags.Append(d...)
+	return listVal
+}
+
+// StringListTo converts a Terraform types.Object back to a cobbler Value[[]string]. An
+// explicitly empty list stays empty rather than inheriting.
+func StringListTo(ctx context.Context, obj types.Object, diags *diag.Diagnostics) cobbler.Value[[]string] {
+	value, ok := collectionValue(obj, diags)
+	if !ok {
 		return cobbler.Value[[]string]{IsInherited: true}
 	}
-	if inherited.ValueBool() {
+	listVal, ok := value.(types.List)
+	if !ok {
 		return cobbler.Value[[]string]{IsInherited: true}
 	}
-	listVal, ok := attrs["value"].(types.List)
-	if !ok || listVal.IsNull() || listVal.IsUnknown() {
-		return cobbler.Value[[]string]{Data: []string{}}
-	}
-	var strs []string
-	d := listVal.ElementsAs(ctx, &strs, false)
-	diags.Append(d...)
-	if strs == nil {
-		strs = []string{}
-	}
+	strs := []string{}
+	diags.Append(listVal.ElementsAs(ctx, &strs, false)...)
 	return cobbler.Value[[]string]{Data: strs}
 }
@@ -0,0 +1,80 @@
+package inherit
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// UpgradeMapState returns the state upgrader from schema version 0, in which the values of
+// the named inheritable map attributes were maps of strings, to version 1, in which they are
+// dynamic. A map of strings becomes an object of strings, the type of the same map written
+// as an object expression, so existing configurations plan no change. All other attributes
+// are kept.
+func UpgradeMapState(names ...string) resource.StateUpgrader {
+	return resource.StateUpgrader{
+		StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+			if req.RawState == nil || req.RawState.JSON == nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", "The prior state is not stored as JSON.")
+				return
+			}
+			var state map[string]json.RawMessage
+			if err := json.Unmarshal(req.RawState.JSON, &state); err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+			for _, name := range names {
+				upgraded, err := upgradeMap(state[name])
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to Upgrade Resource State", name+": "+err.Error())
+					return
+				}
+				state[name] = upgraded
+			}
+
+			raw, err := json.Marshal(state)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+			value, err := (&tfprotov6.RawState{JSON: raw}).UnmarshalWithOpts(resp.State.Schema.Type().TerraformType(ctx), tfprotov6.UnmarshalOpts{
+				ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to Upgrade Resource State", err.Error())
+				return
+			}
+			resp.State.Raw = value
+		},
+	}
+}
+
+// upgradeMap rewrites the value and effective maps of strings of an inheritable map
+// attribute's JSON state into dynamic values.
+func upgradeMap(raw json.RawMessage) (json.RawMessage, error) {
+	var obj map[string]json.RawMessage
+	if raw == nil || json.Unmarshal(raw, &obj) != nil || obj == nil {
+		return raw, nil
+	}
+	for _, field := range []string{"value", "effective"} {
+		var entries map[string]*string
+		if err := json.Unmarshal(obj[field], &entries); err != nil || entries == nil {
+			continue
+		}
+		attrTypes := make(map[string]string, len(entries))
+		for k := range entries {
+			attrTypes[k] = "string"
+		}
+		typ, err := json.Marshal([]interface{}{"object", attrTypes})
+		if err != nil {
+			return nil, err
+		}
+		if obj[field], err = json.Marshal(map[string]json.RawMessage{"type": typ, "value": obj[field]}); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(obj)
+}
@@ -0,0 +1,84 @@
+package inherit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var mapSchema = schema.Schema{
+	Version: 1,
+	Attributes: map[string]schema.Attribute{
+		"name": schema.StringAttribute{Required: true},
+		"kernel_options": schema.SingleNestedAttribute{
+			Optional: true,
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"value":     schema.DynamicAttribute{Optional: true, Computed: true},
+				"inherited": schema.BoolAttribute{Optional: true, Computed: true},
+				"effective": schema.DynamicAttribute{Computed: true},
+			},
+		},
+		"autoinstall_meta": schema.SingleNestedAttribute{
+			Optional: true,
+			Computed: true,
+			Attributes: map[string]schema.Attribute{
+				"value":     schema.DynamicAttribute{Optional: true, Computed: true},
+				"inherited": schema.BoolAttribute{Optional: true, Computed: true},
+				"effective": schema.DynamicAttribute{Computed: true},
+			},
+		},
+	},
+}
+
+func TestUpgradeMapState(t *testing.T) {
+	ctx := context.Background()
+	// Version 0 state, including an attribute the schema no longer has.
+	raw := `{
+		"name": "foo",
+		"kernel_options": {"value": {"console": "ttyS0", "quiet": ""}, "inherited": false},
+		"autoinstall_meta": {"value": null, "inherited": true},
+		"removed": "bar"
+	}`
+	req := resource.UpgradeStateRequest{RawState: &tfprotov6.RawState{JSON: []byte(raw)}}
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: mapSchema}}
+	inherit.UpgradeMapState("kernel_options", "autoinstall_meta").StateUpgrader(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	var name types.String
+	var kernelOptions, autoinstallMeta types.Object
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("name"), &name)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("kernel_options"), &kernelOptions)...)
+	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("autoinstall_meta"), &autoinstallMeta)...)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+	}
+
+	if name.ValueString() != "foo" {
+		t.Errorf("expected the name to be kept, got %v", name)
+	}
+	want := types.DynamicValue(types.ObjectValueMust(
+		map[string]attr.Type{"console": types.StringType, "quiet": types.StringType},
+		map[string]attr.Value{"console": types.StringValue("ttyS0"), "quiet": types.StringValue("")},
+	))
+	attrs := kernelOptions.Attributes()
+	if !attrs["value"].Equal(want) {
+		t.Errorf("expected value %v, got %v", want, attrs["value"])
+	}
+	if !attrs["inherited"].Equal(types.BoolValue(false)) || !attrs["effective"].IsNull() {
+		t.Errorf("expected inherited = false and no effective value, got %v", kernelOptions)
+	}
+	if attrs := autoinstallMeta.Attributes(); !attrs["value"].IsNull() || !attrs["inherited"].Equal(types.BoolValue(true)) {
+		t.Errorf("expected an inherited value, got %v", autoinstallMeta)
+	}
+}
@@ -0,0 +1,53 @@
+package inherit
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Validator returns the validator for inheritable nested objects. It rejects objects that
+// set inherited = true together with a value, which Cobbler would ignore, and objects that
+// set inherited = false without a value, which would silently become the zero value.
+func Validator() validator.Object {
+	return inheritValidator{}
+}
+
+type inheritValidator struct{}
+
+func (v inheritValidator) Description(_ context.Context) string {
+	return "value must be set if and only if inherited is false"
+}
+
+func (v inheritValidator) MarkdownDescription(ctx context.Context) string {
+	return "`value` must be set if and only if `inherited` is false"
+}
+
+func (v inheritValidator) ValidateObject(_ context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	attrs := req.ConfigValue.Attributes()
+	inherited, ok := attrs["inherited"].(types.Bool)
+	if !ok || inherited.IsNull() || inherited.IsUnknown() {
+		return
+	}
+	value := attrs["value"]
+	if value == nil || value.IsUnknown() {
+		return
+	}
+
+	name := req.Path.String()
+	switch {
+	case inherited.ValueBool() && !value.IsNull():
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("value"), "Conflicting inherited value",
+			fmt.Sprintf("%s sets inherited = true, so Cobbler ignores its value. "+
+				"Remove value to inherit from the parent, or set inherited = false to use the value.", name))
+	case !inherited.ValueBool() && value.IsNull():
+		resp.Diagnostics.AddAttributeError(req.Path.AtName("value"), "Missing value",
+			fmt.Sprintf("%s sets inherited = false without a value. "+
+				"Set value, or set inherited = true to inherit from the parent.", name))
+	}
+}
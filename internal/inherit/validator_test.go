@@ -0,0 +1,62 @@
+package inherit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func validateVirtRam(value types.Int64, inherited types.Bool) validator.ObjectResponse {
+	obj := types.ObjectValueMust(inherit.IntAttrTypes, map[string]attr.Value{
+		"value":     value,
+		"inherited": inherited,
+		"effective": types.Int64Null(),
+	})
+	req := validator.ObjectRequest{Path: path.Root("virt_ram"), ConfigValue: obj}
+	var resp validator.ObjectResponse
+	inherit.Validator().ValidateObject(context.Background(), req, &resp)
+	return resp
+}
+
+func TestValidator(t *testing.T) {
+	cases := []struct {
+		name      string
+		value     types.Int64
+		inherited types.Bool
+		wantError string
+	}{
+		{"inherited", types.Int64Null(), types.BoolValue(true), ""},
+		{"explicit", types.Int64Value(2048), types.BoolValue(false), ""},
+		{"inherited flag unset", types.Int64Value(2048), types.BoolNull(), ""},
+		{"unknown value", types.Int64Unknown(), types.BoolValue(true), ""},
+		{"inherited with value", types.Int64Value(2048), types.BoolValue(true), "Conflicting inherited value"},
+		{"explicit without value", types.Int64Null(), types.BoolValue(false), "Missing value"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp := validateVirtRam(tc.value, tc.inherited)
+			if tc.wantError == "" {
+				if resp.Diagnostics.HasError() {
+					t.Errorf("unexpected diagnostics: %v", resp.Diagnostics)
+				}
+				return
+			}
+			if resp.Diagnostics.ErrorsCount() != 1 {
+				t.Fatalf("expected one error, got %v", resp.Diagnostics)
+			}
+			d := resp.Diagnostics.Errors()[0]
+			if d.Summary() != tc.wantError {
+				t.Errorf("expected %q, got %q", tc.wantError, d.Summary())
+			}
+			withPath, ok := d.(interface{ Path() path.Path })
+			if !ok || !withPath.Path().Equal(path.Root("virt_ram").AtName("value")) {
+				t.Errorf("expected the error on virt_ram.value, got %v", d)
+			}
+		})
+	}
+}
@@ -53,11 +53,13 @@ func (d *MenuDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 				Description: "Autoinstall template metadata.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
-						ElementType: types.StringType,
-						Computed:    true,
+					"value": schema.DynamicAttribute{
+						Computed: true,
 					},
 					"inherited": schema.BoolAttribute{Computed: true},
+					"effective": schema.DynamicAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -74,6 +76,10 @@ func (d *MenuDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 						Computed:    true,
 					},
 					"inherited": schema.BoolAttribute{Computed: true},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 		},
@@ -102,18 +108,24 @@ func (d *MenuDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	menuPtr, err := d.client.GetMenu(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Menu", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Menu", err)
 		return
 	}
 	menu := *menuPtr
+	resolvedPtr, err := d.client.GetMenu(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Menu", err)
+		return
+	}
+	resolved := *resolvedPtr
 
 	data.Name = types.StringValue(menu.Name)
 	data.UID = types.StringValue(menu.Uid)
 	data.Comment = types.StringValue(menu.Comment)
 	data.Parent = types.StringValue(menu.Parent)
 	data.DisplayName = types.StringValue(menu.DisplayName)
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, menu.AutoinstallMeta, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, menu.Owners, &resp.Diagnostics)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, menu.AutoinstallMeta, resolved.AutoinstallMeta, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, menu.Owners, resolved.Owners, &resp.Diagnostics)
 
 	templateFiles, d2 := types.MapValueFrom(ctx, types.StringType, menu.TemplateFiles)
 	resp.Diagnostics.Append(d2...)
@@ -2,7 +2,6 @@ package menu
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -12,20 +11,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &MenuResource{}
 var _ resource.ResourceWithImportState = &MenuResource{}
+var _ resource.ResourceWithModifyPlan = &MenuResource{}
+var _ resource.ResourceWithUpgradeState = &MenuResource{}
+
+// menuAttributePaths maps Cobbler fields to schema paths for validation errors.
+var menuAttributePaths = clientpkg.AttributePathsFromModel(menuResourceModel{})
 
 type MenuResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
+// menuParents are the attributes referencing the items a menu inherits from.
+var menuParents = []path.Path{path.Root("parent")}
+
 func NewResource() resource.Resource {
 	return &MenuResource{}
 }
@@ -37,6 +47,7 @@ func (r *MenuResource) Metadata(_ context.Context, req resource.MetadataRequest,
 func (r *MenuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "`cobbler_menu` manages a boot menu within Cobbler.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "A name for the menu. Changing this forces a new resource.",
@@ -81,7 +92,10 @@ func (r *MenuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(menuParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: inheritedMapAttrs(),
 			},
@@ -99,7 +113,10 @@ func (r *MenuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(menuParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: inheritedListAttrs(),
 			},
@@ -108,18 +125,21 @@ func (r *MenuResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 }
 
 // inheritedMapAttrs returns the sub-attributes for a SingleNestedAttribute
-// that wraps a Cobbler Value[map[string]interface{}] (inherited or explicit map).
+// that wraps a Cobbler Value[map[string]interface{}] (inherited or explicit map). The map
+// is a dynamic object, so its entries keep their types.
 func inheritedMapAttrs() map[string]schema.Attribute {
 	return map[string]schema.Attribute{
-		"value": schema.MapAttribute{
-			ElementType: types.StringType,
-			Optional:    true,
-			Computed:    true,
+		"value": schema.DynamicAttribute{
+			Optional: true,
+			Computed: true,
 		},
 		"inherited": schema.BoolAttribute{
 			Optional: true,
 			Computed: true,
 		},
+		"effective": schema.DynamicAttribute{
+			Computed: true,
+		},
 	}
 }
 
@@ -136,6 +156,10 @@ func inheritedListAttrs() map[string]schema.Attribute {
 			Optional: true,
 			Computed: true,
 		},
+		"effective": schema.ListAttribute{
+			ElementType: types.StringType,
+			Computed:    true,
+		},
 	}
 }
 
@@ -150,9 +174,27 @@ func (r *MenuResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *MenuResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp, menuParents...)
+}
+
+// UpgradeState upgrades state written before the map values became dynamic.
+func (r *MenuResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: inherit.UpgradeMapState("autoinstall_meta"),
+	}
 }
 
 func (r *MenuResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data menuResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -168,16 +210,29 @@ func (r *MenuResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	newMenu, err := r.client.CreateMenu(menu)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler Menu", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Menu", err, menuAttributePaths)
 		return
 	}
 
-	menuToModel(ctx, *newMenu, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetMenu(newMenu.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Menu", err)
+		return
+	}
+	menuToModel(ctx, *newMenu, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *MenuResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -189,15 +244,20 @@ func (r *MenuResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	menu, err := r.client.GetMenu(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler Menu", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Menu", err, menuAttributePaths)
 		return
 	}
 
-	menuToModel(ctx, *menu, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetMenu(menu.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Menu", err)
+		return
+	}
+	menuToModel(ctx, *menu, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -206,6 +266,10 @@ func (r *MenuResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *MenuResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data menuResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -220,17 +284,27 @@ func (r *MenuResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	tflog.Debug(ctx, "Cobbler Menu: Update", map[string]interface{}{"name": menu.Name})
 
 	if err := r.client.UpdateMenu(&menu); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler Menu", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Menu", err, menuAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updatedMenu, err := r.client.GetMenu(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Menu after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Menu after update", err, menuAttributePaths)
 		return
 	}
 
-	menuToModel(ctx, *updatedMenu, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetMenu(updatedMenu.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Menu", err)
+		return
+	}
+	menuToModel(ctx, *updatedMenu, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -239,6 +313,10 @@ func (r *MenuResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *MenuResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data menuResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -248,7 +326,12 @@ func (r *MenuResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	tflog.Debug(ctx, "Cobbler Menu: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteMenu(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Menu", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Menu", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -263,7 +346,7 @@ func modelToMenu(ctx context.Context, data menuResourceModel, diags *diag.Diagno
 	menu.Comment = data.Comment.ValueString()
 	menu.Parent = data.Parent.ValueString()
 	menu.DisplayName = data.DisplayName.ValueString()
-	menu.AutoinstallMeta = inherit.StringMapTo(ctx, data.AutoinstallMeta, diags)
+	menu.AutoinstallMeta = inherit.MapTo(ctx, data.AutoinstallMeta, diags)
 	menu.Owners = inherit.StringListTo(ctx, data.Owners, diags)
 
 	var templateFiles map[string]string
@@ -276,14 +359,14 @@ func modelToMenu(ctx context.Context, data menuResourceModel, diags *diag.Diagno
 }
 
 // menuToModel populates a menuResourceModel from a cobbler.Menu.
-func menuToModel(ctx context.Context, menu cobbler.Menu, data *menuResourceModel, diags *diag.Diagnostics) {
+func menuToModel(ctx context.Context, menu cobbler.Menu, resolved cobbler.Menu, data *menuResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(menu.Name)
 	data.UID = types.StringValue(menu.Uid)
 	data.Comment = types.StringValue(menu.Comment)
 	data.Parent = types.StringValue(menu.Parent)
 	data.DisplayName = types.StringValue(menu.DisplayName)
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, menu.AutoinstallMeta, diags)
-	data.Owners = inherit.StringListFrom(ctx, menu.Owners, diags)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, menu.AutoinstallMeta, resolved.AutoinstallMeta, data.AutoinstallMeta, diags)
+	data.Owners = inherit.StringListFrom(ctx, menu.Owners, resolved.Owners, diags)
 
 	templateFiles, d := types.MapValueFrom(ctx, types.StringType, menu.TemplateFiles)
 	diags.Append(d...)
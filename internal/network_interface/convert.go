@@ -204,7 +204,7 @@ func modelToInterface(ctx context.Context, data networkInterfaceResourceModel, d
 }
 
 // interfaceToModel populates a resource model from a NetworkInterface.
-func interfaceToModel(ctx context.Context, iface cobbler.NetworkInterface, data *networkInterfaceResourceModel, diags *diag.Diagnostics) {
+func interfaceToModel(ctx context.Context, iface cobbler.NetworkInterface, resolved cobbler.NetworkInterface, data *networkInterfaceResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(iface.Name)
 	data.System = types.StringValue(iface.SystemUid)
 	data.SystemName = types.StringValue(iface.SystemName)
@@ -220,14 +220,14 @@ func interfaceToModel(ctx context.Context, iface cobbler.NetworkInterface, data
 	data.DHCPTag = types.StringValue(iface.DHCPTag)
 	data.IfGateway = types.StringValue(iface.IfGateway)
 	data.MTU = types.StringValue(iface.MTU)
-	data.VirtBridge = inherit.StringFrom(ctx, iface.VirtBridge, diags)
+	data.VirtBridge = inherit.StringFrom(ctx, iface.VirtBridge, resolved.VirtBridge, diags)
 	data.IPv4 = ipv4FromAPI(ctx, iface.IPv4, iface.IfGateway, diags)
 	data.IPv6 = ipv6FromAPI(ctx, iface.IPv6, iface.Ipv6DefaultGateway, diags)
 	data.DNS = dnsFromAPI(ctx, iface.DNS, diags)
 }
 
 // interfaceToDataSourceModel populates a data source model from a NetworkInterface.
-func interfaceToDataSourceModel(ctx context.Context, iface cobbler.NetworkInterface, data *networkInterfaceDataSourceModel, diags *diag.Diagnostics) {
+func interfaceToDataSourceModel(ctx context.Context, iface cobbler.NetworkInterface, resolved cobbler.NetworkInterface, data *networkInterfaceDataSourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(iface.Name)
 	data.System = types.StringValue(iface.SystemUid)
 	data.SystemName = types.StringValue(iface.SystemName)
@@ -243,7 +243,7 @@ func interfaceToDataSourceModel(ctx context.Context, iface cobbler.NetworkInterf
 	data.DHCPTag = types.StringValue(iface.DHCPTag)
 	data.IfGateway = types.StringValue(iface.IfGateway)
 	data.MTU = types.StringValue(iface.MTU)
-	data.VirtBridge = inherit.StringFrom(ctx, iface.VirtBridge, diags)
+	data.VirtBridge = inherit.StringFrom(ctx, iface.VirtBridge, resolved.VirtBridge, diags)
 	data.IPv4 = ipv4FromAPI(ctx, iface.IPv4, iface.IfGateway, diags)
 	data.IPv6 = ipv6FromAPI(ctx, iface.IPv6, iface.Ipv6DefaultGateway, diags)
 	data.DNS = dnsFromAPI(ctx, iface.DNS, diags)
@@ -52,6 +52,7 @@ func (d *NetworkInterfaceDataSource) Schema(_ context.Context, _ datasource.Sche
 				Attributes: map[string]dsschema.Attribute{
 					"value":     dsschema.StringAttribute{Computed: true},
 					"inherited": dsschema.BoolAttribute{Computed: true},
+					"effective": dsschema.StringAttribute{Computed: true},
 				},
 			},
 			"ipv4": dsschema.SingleNestedAttribute{
@@ -110,11 +111,16 @@ func (d *NetworkInterfaceDataSource) Read(ctx context.Context, req datasource.Re
 
 	iface, err := d.client.GetNetworkInterface(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler NetworkInterface", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler NetworkInterface", err)
+		return
+	}
+	resolved, err := d.client.GetNetworkInterface(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler NetworkInterface", err)
 		return
 	}
 
-	interfaceToDataSourceModel(ctx, *iface, &data, &resp.Diagnostics)
+	interfaceToDataSourceModel(ctx, *iface, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
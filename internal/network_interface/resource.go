@@ -2,10 +2,10 @@ package network_interface
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,11 +23,27 @@ import (
 
 var _ resource.Resource = &NetworkInterfaceResource{}
 var _ resource.ResourceWithImportState = &NetworkInterfaceResource{}
+var _ resource.ResourceWithModifyPlan = &NetworkInterfaceResource{}
+
+// networkInterfaceAttributePaths maps Cobbler interface fields to schema paths for validation errors.
+var networkInterfaceAttributePaths = func() clientpkg.AttributePaths {
+	paths := clientpkg.AttributePathsFromModel(networkInterfaceResourceModel{})
+	paths["ip_address"] = path.Root("ipv4").AtName("address")
+	paths["ipv6_address"] = path.Root("ipv6").AtName("address")
+	paths["dns_name"] = path.Root("dns").AtName("name")
+	return paths
+}()
 
 type NetworkInterfaceResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
+// interfaceParents are the attributes referencing the items an interface inherits from.
+var interfaceParents = []path.Path{path.Root("system")}
+
 func NewResource() resource.Resource {
 	return &NetworkInterfaceResource{}
 }
@@ -166,7 +182,10 @@ func (r *NetworkInterfaceResource) Schema(_ context.Context, _ resource.SchemaRe
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(interfaceParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.StringAttribute{
@@ -182,6 +201,10 @@ func (r *NetworkInterfaceResource) Schema(_ context.Context, _ resource.SchemaRe
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.StringAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"ipv4": schema.SingleNestedAttribute{
@@ -329,9 +352,20 @@ func (r *NetworkInterfaceResource) Configure(_ context.Context, req resource.Con
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *NetworkInterfaceResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp, interfaceParents...)
 }
 
 func (r *NetworkInterfaceResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data networkInterfaceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -344,27 +378,41 @@ func (r *NetworkInterfaceResource) Create(ctx context.Context, req resource.Crea
 		return
 	}
 
-	mu := lockForSystem(systemUid)
-	mu.Lock()
-	defer mu.Unlock()
-
 	tflog.Debug(ctx, "Cobbler NetworkInterface: Create", map[string]interface{}{
 		"name":   iface.Name,
 		"system": systemUid,
 	})
 
+	// Only the mutation itself is serialized per system; the sync below may be
+	// coalesced with sibling interfaces and must not hold the lock while waiting.
+	mu := lockForSystem(systemUid)
+	mu.Lock()
 	created, err := r.client.CreateNetworkInterface(systemUid, iface)
+	mu.Unlock()
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler NetworkInterface", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler NetworkInterface", err, networkInterfaceAttributePaths)
 		return
 	}
 
-	interfaceToModel(ctx, *created, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetNetworkInterface(created.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler NetworkInterface", err)
+		return
+	}
+	interfaceToModel(ctx, *created, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.SyncSystems(ctx, created.SystemName); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *NetworkInterfaceResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -376,15 +424,20 @@ func (r *NetworkInterfaceResource) Read(ctx context.Context, req resource.ReadRe
 
 	iface, err := r.client.GetNetworkInterface(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler NetworkInterface", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler NetworkInterface", err, networkInterfaceAttributePaths)
 		return
 	}
 
-	interfaceToModel(ctx, *iface, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetNetworkInterface(iface.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler NetworkInterface", err)
+		return
+	}
+	interfaceToModel(ctx, *iface, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -393,6 +446,10 @@ func (r *NetworkInterfaceResource) Read(ctx context.Context, req resource.ReadRe
 }
 
 func (r *NetworkInterfaceResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data networkInterfaceResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -405,24 +462,34 @@ func (r *NetworkInterfaceResource) Update(ctx context.Context, req resource.Upda
 		return
 	}
 
+	tflog.Debug(ctx, "Cobbler NetworkInterface: Update", map[string]interface{}{"name": iface.Name})
+
 	mu := lockForSystem(systemUid)
 	mu.Lock()
-	defer mu.Unlock()
-
-	tflog.Debug(ctx, "Cobbler NetworkInterface: Update", map[string]interface{}{"name": iface.Name})
+	err := r.client.UpdateNetworkInterface(&iface)
+	mu.Unlock()
+	if err != nil {
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler NetworkInterface", err, networkInterfaceAttributePaths)
+		return
+	}
 
-	if err := r.client.UpdateNetworkInterface(&iface); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler NetworkInterface", err.Error())
+	if err := r.syncer.SyncSystems(ctx, data.SystemName.ValueString()); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updated, err := r.client.GetNetworkInterface(iface.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler NetworkInterface after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler NetworkInterface after update", err, networkInterfaceAttributePaths)
 		return
 	}
 
-	interfaceToModel(ctx, *updated, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetNetworkInterface(updated.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler NetworkInterface", err)
+		return
+	}
+	interfaceToModel(ctx, *updated, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -431,21 +498,29 @@ func (r *NetworkInterfaceResource) Update(ctx context.Context, req resource.Upda
 }
 
 func (r *NetworkInterfaceResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data networkInterfaceResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	systemUid := data.System.ValueString()
-	mu := lockForSystem(systemUid)
-	mu.Lock()
-	defer mu.Unlock()
-
 	tflog.Debug(ctx, "Cobbler NetworkInterface: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
-	if err := r.client.DeleteNetworkInterface(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler NetworkInterface", err.Error())
+	mu := lockForSystem(data.System.ValueString())
+	mu.Lock()
+	err := r.client.DeleteNetworkInterface(data.Name.ValueString())
+	mu.Unlock()
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler NetworkInterface", err)
+		return
+	}
+
+	if err := r.syncer.SyncSystems(ctx, data.SystemName.ValueString()); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
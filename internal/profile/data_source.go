@@ -106,15 +106,18 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 				Description: "Automatic installation template metadata, formerly Kickstart metadata.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"enable_ipxe": schema.SingleNestedAttribute{
@@ -129,6 +132,10 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"enable_menu": schema.SingleNestedAttribute{
@@ -143,36 +150,46 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": schema.SingleNestedAttribute{
 				Description: "Kernel options for the profile.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
 				Description: "Post install kernel options.",
 				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"name_servers_search": schema.ListAttribute{
@@ -193,6 +210,11 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -208,6 +230,11 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -227,6 +254,10 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_file_size": schema.SingleNestedAttribute{
@@ -241,6 +272,10 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.Float64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_ram": schema.SingleNestedAttribute{
@@ -255,6 +290,10 @@ func (d *ProfileDataSource) Schema(_ context.Context, _ datasource.SchemaRequest
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": schema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 		},
@@ -283,10 +322,16 @@ func (d *ProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 
 	profilePtr, err := d.client.GetProfile(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Profile", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Profile", err)
 		return
 	}
 	p := *profilePtr
+	resolvedPtr, err := d.client.GetProfile(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Profile", err)
+		return
+	}
+	resolved := *resolvedPtr
 
 	data.Name = types.StringValue(p.Name)
 	data.UID = types.StringValue(p.Uid)
@@ -318,16 +363,16 @@ func (d *ProfileDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	resp.Diagnostics.Append(diag3...)
 	data.TemplateFiles = templateFiles
 
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, p.AutoinstallMeta, &resp.Diagnostics)
-	data.EnableIPXE = inherit.BoolFrom(ctx, p.EnableIPXE, &resp.Diagnostics)
-	data.EnableMenu = inherit.BoolFrom(ctx, p.EnableMenu, &resp.Diagnostics)
-	data.KernelOptions = inherit.StringMapFrom(ctx, p.KernelOptions, &resp.Diagnostics)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, p.KernelOptionsPost, &resp.Diagnostics)
-	data.NameServers = inherit.StringListFrom(ctx, p.DNS.NameServers, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, p.Owners, &resp.Diagnostics)
-	data.VirtAutoBoot = inherit.BoolFrom(ctx, p.Virt.AutoBoot, &resp.Diagnostics)
-	data.VirtFileSize = inherit.Float64From(ctx, p.Virt.FileSize, &resp.Diagnostics)
-	data.VirtRAM = inherit.IntFrom(ctx, p.Virt.Ram, &resp.Diagnostics)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, p.AutoinstallMeta, resolved.AutoinstallMeta, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.EnableIPXE = inherit.BoolFrom(ctx, p.EnableIPXE, resolved.EnableIPXE, &resp.Diagnostics)
+	data.EnableMenu = inherit.BoolFrom(ctx, p.EnableMenu, resolved.EnableMenu, &resp.Diagnostics)
+	data.KernelOptions = inherit.MapFrom(ctx, p.KernelOptions, resolved.KernelOptions, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, p.KernelOptionsPost, resolved.KernelOptionsPost, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.NameServers = inherit.StringListFrom(ctx, p.DNS.NameServers, resolved.DNS.NameServers, &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, p.Owners, resolved.Owners, &resp.Diagnostics)
+	data.VirtAutoBoot = inherit.BoolFrom(ctx, p.Virt.AutoBoot, resolved.Virt.AutoBoot, &resp.Diagnostics)
+	data.VirtFileSize = inherit.Float64From(ctx, p.Virt.FileSize, resolved.Virt.FileSize, &resp.Diagnostics)
+	data.VirtRAM = inherit.IntFrom(ctx, p.Virt.Ram, resolved.Virt.Ram, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
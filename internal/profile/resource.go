@@ -2,7 +2,6 @@ package profile
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -15,20 +14,31 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &ProfileResource{}
 var _ resource.ResourceWithImportState = &ProfileResource{}
+var _ resource.ResourceWithModifyPlan = &ProfileResource{}
+var _ resource.ResourceWithUpgradeState = &ProfileResource{}
+
+// profileAttributePaths maps Cobbler fields to schema paths for validation errors.
+var profileAttributePaths = clientpkg.AttributePathsFromModel(profileResourceModel{})
 
 type ProfileResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
+// profileParents are the attributes referencing the items a profile inherits from.
+var profileParents = []path.Path{path.Root("parent"), path.Root("distro")}
+
 func NewResource() resource.Resource {
 	return &ProfileResource{}
 }
@@ -40,6 +50,7 @@ func (r *ProfileResource) Metadata(_ context.Context, req resource.MetadataReque
 func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "`cobbler_profile` manages a profile within Cobbler.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "The name of the profile.",
@@ -185,14 +196,16 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -202,6 +215,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"enable_ipxe": schema.SingleNestedAttribute{
@@ -209,7 +226,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.BoolAttribute{
@@ -225,6 +245,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"enable_menu": schema.SingleNestedAttribute{
@@ -232,7 +256,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.BoolAttribute{
@@ -248,6 +275,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": schema.SingleNestedAttribute{
@@ -255,14 +286,16 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -272,6 +305,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
@@ -279,14 +316,16 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -296,6 +335,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"name_servers_search": schema.ListAttribute{
@@ -312,7 +355,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -329,6 +375,11 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -336,7 +387,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -353,6 +407,11 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -369,7 +428,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.BoolAttribute{
@@ -385,6 +447,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_file_size": schema.SingleNestedAttribute{
@@ -392,7 +458,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Float64Attribute{
@@ -408,6 +477,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Float64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_ram": schema.SingleNestedAttribute{
@@ -415,7 +488,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(profileParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Int64Attribute{
@@ -431,6 +507,10 @@ func (r *ProfileResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 		},
@@ -448,9 +528,27 @@ func (r *ProfileResource) Configure(_ context.Context, req resource.ConfigureReq
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *ProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp, profileParents...)
+}
+
+// UpgradeState upgrades state written before the map values became dynamic.
+func (r *ProfileResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: inherit.UpgradeMapState("autoinstall_meta", "kernel_options", "kernel_options_post"),
+	}
 }
 
 func (r *ProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -466,16 +564,29 @@ func (r *ProfileResource) Create(ctx context.Context, req resource.CreateRequest
 
 	newProfile, err := r.client.CreateProfile(profile)
 	if err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error creating Cobbler Profile", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Profile", err, profileAttributePaths)
 		return
 	}
 
-	profileToModel(ctx, *newProfile, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetProfile(newProfile.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Profile", err)
+		return
+	}
+	profileToModel(ctx, *newProfile, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *ProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -487,15 +598,20 @@ func (r *ProfileResource) Read(ctx context.Context, req resource.ReadRequest, re
 
 	profile, err := r.client.GetProfile(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Profile", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Profile", err, profileAttributePaths)
 		return
 	}
 
-	profileToModel(ctx, *profile, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetProfile(profile.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Profile", err)
+		return
+	}
+	profileToModel(ctx, *profile, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -504,6 +620,10 @@ func (r *ProfileResource) Read(ctx context.Context, req resource.ReadRequest, re
 }
 
 func (r *ProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -518,17 +638,27 @@ func (r *ProfileResource) Update(ctx context.Context, req resource.UpdateRequest
 	tflog.Debug(ctx, "Cobbler Profile: Update", map[string]interface{}{"name": profile.Name})
 
 	if err := r.client.UpdateProfile(&profile); err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error updating Cobbler Profile", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Profile", err, profileAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updatedProfile, err := r.client.GetProfile(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Profile after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Profile after update", err, profileAttributePaths)
 		return
 	}
 
-	profileToModel(ctx, *updatedProfile, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetProfile(updatedProfile.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Profile", err)
+		return
+	}
+	profileToModel(ctx, *updatedProfile, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -537,6 +667,10 @@ func (r *ProfileResource) Update(ctx context.Context, req resource.UpdateRequest
 }
 
 func (r *ProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -546,7 +680,12 @@ func (r *ProfileResource) Delete(ctx context.Context, req resource.DeleteRequest
 	tflog.Debug(ctx, "Cobbler Profile: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteProfile(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Profile", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Profile", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -602,11 +741,11 @@ func modelToProfile(ctx context.Context, data profileResourceModel, diags *diag.
 	}
 	profile.TemplateFiles = templateFiles
 
-	profile.AutoinstallMeta = inherit.StringMapTo(ctx, data.AutoinstallMeta, diags)
+	profile.AutoinstallMeta = inherit.MapTo(ctx, data.AutoinstallMeta, diags)
 	profile.EnableIPXE = inherit.BoolTo(ctx, data.EnableIPXE, diags)
 	profile.EnableMenu = inherit.BoolTo(ctx, data.EnableMenu, diags)
-	profile.KernelOptions = inherit.StringMapTo(ctx, data.KernelOptions, diags)
-	profile.KernelOptionsPost = inherit.StringMapTo(ctx, data.KernelOptionsPost, diags)
+	profile.KernelOptions = inherit.MapTo(ctx, data.KernelOptions, diags)
+	profile.KernelOptionsPost = inherit.MapTo(ctx, data.KernelOptionsPost, diags)
 	profile.DNS.NameServers = inherit.StringListTo(ctx, data.NameServers, diags)
 	profile.Owners = inherit.StringListTo(ctx, data.Owners, diags)
 	profile.Virt.AutoBoot = inherit.BoolTo(ctx, data.VirtAutoBoot, diags)
@@ -617,7 +756,7 @@ func modelToProfile(ctx context.Context, data profileResourceModel, diags *diag.
 }
 
 // profileToModel populates a profileResourceModel from a cobbler.Profile.
-func profileToModel(ctx context.Context, profile cobbler.Profile, data *profileResourceModel, diags *diag.Diagnostics) {
+func profileToModel(ctx context.Context, profile cobbler.Profile, resolved cobbler.Profile, data *profileResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(profile.Name)
 	data.UID = types.StringValue(profile.Uid)
 	data.Autoinstall = types.StringValue(profile.Autoinstall)
@@ -648,14 +787,14 @@ func profileToModel(ctx context.Context, profile cobbler.Profile, data *profileR
 	diags.Append(d...)
 	data.TemplateFiles = templateFiles
 
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, profile.AutoinstallMeta, diags)
-	data.EnableIPXE = inherit.BoolFrom(ctx, profile.EnableIPXE, diags)
-	data.EnableMenu = inherit.BoolFrom(ctx, profile.EnableMenu, diags)
-	data.KernelOptions = inherit.StringMapFrom(ctx, profile.KernelOptions, diags)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, profile.KernelOptionsPost, diags)
-	data.NameServers = inherit.StringListFrom(ctx, profile.DNS.NameServers, diags)
-	data.Owners = inherit.StringListFrom(ctx, profile.Owners, diags)
-	data.VirtAutoBoot = inherit.BoolFrom(ctx, profile.Virt.AutoBoot, diags)
-	data.VirtFileSize = inherit.Float64From(ctx, profile.Virt.FileSize, diags)
-	data.VirtRAM = inherit.IntFrom(ctx, profile.Virt.Ram, diags)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, profile.AutoinstallMeta, resolved.AutoinstallMeta, data.AutoinstallMeta, diags)
+	data.EnableIPXE = inherit.BoolFrom(ctx, profile.EnableIPXE, resolved.EnableIPXE, diags)
+	data.EnableMenu = inherit.BoolFrom(ctx, profile.EnableMenu, resolved.EnableMenu, diags)
+	data.KernelOptions = inherit.MapFrom(ctx, profile.KernelOptions, resolved.KernelOptions, data.KernelOptions, diags)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, profile.KernelOptionsPost, resolved.KernelOptionsPost, data.KernelOptionsPost, diags)
+	data.NameServers = inherit.StringListFrom(ctx, profile.DNS.NameServers, resolved.DNS.NameServers, diags)
+	data.Owners = inherit.StringListFrom(ctx, profile.Owners, resolved.Owners, diags)
+	data.VirtAutoBoot = inherit.BoolFrom(ctx, profile.Virt.AutoBoot, resolved.Virt.AutoBoot, diags)
+	data.VirtFileSize = inherit.Float64From(ctx, profile.Virt.FileSize, resolved.Virt.FileSize, diags)
+	data.VirtRAM = inherit.IntFrom(ctx, profile.Virt.Ram, resolved.Virt.Ram, diags)
 }
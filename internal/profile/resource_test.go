@@ -1,6 +1,7 @@
 package profile_test
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
@@ -227,12 +228,14 @@ func TestAccProfileResource_virtRamExplicit(t *testing.T) {
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "name", "foo-resource-profile-virt-ram"),
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.inherited", "false"),
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.value", "2048"),
+					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.effective", "2048"),
 				),
 			},
 			{
 				Config: testAccProfileResourceVirtRamInherited,
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.inherited", "true"),
+					resource.TestCheckResourceAttrSet("cobbler_profile.foo", "virt_ram.effective"),
 				),
 			},
 			{
@@ -240,6 +243,7 @@ func TestAccProfileResource_virtRamExplicit(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.inherited", "false"),
 					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.value", "2048"),
+					resource.TestCheckResourceAttr("cobbler_profile.foo", "virt_ram.effective", "2048"),
 				),
 			},
 			{
@@ -253,6 +257,27 @@ func TestAccProfileResource_virtRamExplicit(t *testing.T) {
 	})
 }
 
+// TestAccProfileResource_inheritValidation checks that contradictory inherit objects are
+// rejected before anything is sent to Cobbler.
+func TestAccProfileResource_inheritValidation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProfileResourceVirtRamConflict,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Conflicting inherited value`),
+			},
+			{
+				Config:      testAccProfileResourceVirtRamMissing,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Missing value`),
+			},
+		},
+	})
+}
+
 const testAccProfileResourceVirtFileSizeExplicit = `
 resource "cobbler_distro" "foo" {
   name       = "foo-resource-profile-virt-file-size"
@@ -349,3 +374,91 @@ resource "cobbler_profile" "foo" {
   }
 }
 `
+
+const testAccProfileResourceVirtRamConflict = `
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-profile-virt-ram-conflict"
+  distro = "unused"
+  virt_ram = {
+    inherited = true
+    value     = 2048
+  }
+}
+`
+
+const testAccProfileResourceVirtRamMissing = `
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-profile-virt-ram-missing"
+  distro = "unused"
+  virt_ram = {
+    inherited = false
+  }
+}
+`
+
+// TestAccProfileResource_parentChangedInPlace changes the distro's kernel options in place. The
+// profile keeps its distro reference, yet its effective kernel options follow the distro's.
+func TestAccProfileResource_parentChangedInPlace(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProfileResourceParentInPlace1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_profile.foo", "kernel_options.effective.foo", "bar"),
+				),
+			},
+			{
+				Config: testAccProfileResourceParentInPlace2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_profile.foo", "kernel_options.effective.foo", "baz"),
+				),
+			},
+		},
+	})
+}
+
+const testAccProfileResourceParentInPlace1 = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-profile-parent-in-place"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+  kernel_options = {
+    value = { foo = "bar" }
+  }
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-profile-parent-in-place"
+  distro = cobbler_distro.foo.uid
+  kernel_options = {
+    inherited = true
+  }
+}
+`
+
+const testAccProfileResourceParentInPlace2 = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-profile-parent-in-place"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+  kernel_options = {
+    value = { foo = "baz" }
+  }
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-profile-parent-in-place"
+  distro = cobbler_distro.foo.uid
+  kernel_options = {
+    inherited = true
+  }
+}
+`
@@ -57,7 +57,7 @@ func (d *ProfileGroupDataSource) Read(ctx context.Context, req datasource.ReadRe
 
 	g, err := d.client.GetProfileGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler ProfileGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler ProfileGroup", err)
 		return
 	}
 
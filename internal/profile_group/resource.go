@@ -2,7 +2,6 @@ package profile_group
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -20,8 +19,12 @@ import (
 var _ resource.Resource = &ProfileGroupResource{}
 var _ resource.ResourceWithImportState = &ProfileGroupResource{}
 
+// profileGroupAttributePaths maps Cobbler fields to schema paths for validation errors.
+var profileGroupAttributePaths = clientpkg.AttributePathsFromModel(profileGroupResourceModel{})
+
 type ProfileGroupResource struct {
 	client cobbler.Client
+	writes *clientpkg.WriteGuard
 }
 
 func NewResource() resource.Resource {
@@ -75,6 +78,7 @@ func (r *ProfileGroupResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.writes = cfg.Writes
 }
 
 func modelToGroup(ctx context.Context, data profileGroupResourceModel, diags *diag.Diagnostics) cobbler.ProfileGroup {
@@ -120,6 +124,10 @@ func groupToDataSourceModel(ctx context.Context, g cobbler.ProfileGroup, data *p
 }
 
 func (r *ProfileGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -135,7 +143,7 @@ func (r *ProfileGroupResource) Create(ctx context.Context, req resource.CreateRe
 
 	created, err := r.client.CreateProfileGroup(g)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler ProfileGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler ProfileGroup", err, profileGroupAttributePaths)
 		return
 	}
 
@@ -155,11 +163,11 @@ func (r *ProfileGroupResource) Read(ctx context.Context, req resource.ReadReques
 
 	g, err := r.client.GetProfileGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler ProfileGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler ProfileGroup", err, profileGroupAttributePaths)
 		return
 	}
 
@@ -171,6 +179,10 @@ func (r *ProfileGroupResource) Read(ctx context.Context, req resource.ReadReques
 }
 
 func (r *ProfileGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -185,13 +197,13 @@ func (r *ProfileGroupResource) Update(ctx context.Context, req resource.UpdateRe
 	tflog.Debug(ctx, "Cobbler ProfileGroup: Update", map[string]interface{}{"name": g.Name})
 
 	if err := r.client.UpdateProfileGroup(&g); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler ProfileGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler ProfileGroup", err, profileGroupAttributePaths)
 		return
 	}
 
 	updated, err := r.client.GetProfileGroup(g.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler ProfileGroup after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler ProfileGroup after update", err, profileGroupAttributePaths)
 		return
 	}
 
@@ -203,6 +215,10 @@ func (r *ProfileGroupResource) Update(ctx context.Context, req resource.UpdateRe
 }
 
 func (r *ProfileGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data profileGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,7 +228,7 @@ func (r *ProfileGroupResource) Delete(ctx context.Context, req resource.DeleteRe
 	tflog.Debug(ctx, "Cobbler ProfileGroup: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteProfileGroup(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler ProfileGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler ProfileGroup", err)
 	}
 }
 
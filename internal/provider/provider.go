@@ -2,26 +2,46 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/cobbler/terraform-provider-cobbler/internal/blended"
+	"github.com/cobbler/terraform-provider-cobbler/internal/boot_config"
+	"github.com/cobbler/terraform-provider-cobbler/internal/buildiso"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
 	"github.com/cobbler/terraform-provider-cobbler/internal/distro"
 	"github.com/cobbler/terraform-provider-cobbler/internal/distro_group"
+	"github.com/cobbler/terraform-provider-cobbler/internal/distro_import"
 	"github.com/cobbler/terraform-provider-cobbler/internal/image"
 	"github.com/cobbler/terraform-provider-cobbler/internal/menu"
 	"github.com/cobbler/terraform-provider-cobbler/internal/network_interface"
 	"github.com/cobbler/terraform-provider-cobbler/internal/profile"
 	"github.com/cobbler/terraform-provider-cobbler/internal/profile_group"
+	"github.com/cobbler/terraform-provider-cobbler/internal/rendered_autoinstall"
 	"github.com/cobbler/terraform-provider-cobbler/internal/repo"
+	"github.com/cobbler/terraform-provider-cobbler/internal/reposync"
+	"github.com/cobbler/terraform-provider-cobbler/internal/setting"
 	"github.com/cobbler/terraform-provider-cobbler/internal/system"
 	"github.com/cobbler/terraform-provider-cobbler/internal/system_group"
+	"github.com/cobbler/terraform-provider-cobbler/internal/system_netboot"
+	"github.com/cobbler/terraform-provider-cobbler/internal/system_power"
 	"github.com/cobbler/terraform-provider-cobbler/internal/template"
 	"github.com/cobbler/terraform-provider-cobbler/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -59,6 +79,29 @@ func (p *CobblerProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"password_file": schema.StringAttribute{
+				Description: "The path to a file containing the password to the Cobbler service. Used if `password` is not set. " +
+					"This can also be specified with the `COBBLER_PASSWORD_FILE` shell environment variable.",
+				Optional: true,
+			},
+			"password_command": schema.StringAttribute{
+				Description: "A shell command whose output is the password to the Cobbler service, e.g. `pass show cobbler`. " +
+					"Used if neither `password` nor `password_file` is set. " +
+					"This can also be specified with the `COBBLER_PASSWORD_COMMAND` shell environment variable.",
+				Optional: true,
+			},
+			"config_file": schema.StringAttribute{
+				Description: "The path to a YAML config file in the format of the `cobbler` CLI (`server_url`, `server_username`, `server_password`), " +
+					"optionally with named server `profiles`. Settings from the file are used for anything not set in the provider configuration or environment. " +
+					"Defaults to `~/.cobbler.yaml` if it exists. " +
+					"This can also be specified with the `COBBLER_CONFIG_FILE` shell environment variable.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "The name of the server profile to use from `config_file`. Defaults to the file's top-level settings. " +
+					"This can also be specified with the `COBBLER_PROFILE` shell environment variable.",
+				Optional: true,
+			},
 			"insecure": schema.BoolAttribute{
 				Description: "If set to true, SSL certificate errors are ignored. This can also be specified with the `COBBLER_INSECURE` shell environment variable.",
 				Optional:    true,
@@ -67,17 +110,120 @@ func (p *CobblerProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 				Description: "The path or contents of an SSL CA certificate. This can also be specified with the `COBBLER_CACERT_FILE` shell environment variable.",
 				Optional:    true,
 			},
+			"cacert_append": schema.BoolAttribute{
+				Description: "If set to true, the certificates from `cacert_file` are trusted in addition to the system's CA certificates instead of replacing them. " +
+					"This can also be specified with the `COBBLER_CACERT_APPEND` shell environment variable.",
+				Optional: true,
+			},
+			"client_cert_file": schema.StringAttribute{
+				Description: "The path or contents of a PEM client certificate for mutual TLS. Requires `client_key_file`. " +
+					"This can also be specified with the `COBBLER_CLIENT_CERT_FILE` shell environment variable.",
+				Optional: true,
+			},
+			"client_key_file": schema.StringAttribute{
+				Description: "The path or contents of the PEM private key for `client_cert_file`. " +
+					"This can also be specified with the `COBBLER_CLIENT_KEY_FILE` shell environment variable.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				Description: "The host name the Cobbler server certificate is verified against, if it differs from the host in `url`. " +
+					"This can also be specified with the `COBBLER_TLS_SERVER_NAME` shell environment variable.",
+				Optional: true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				Description: "The minimum TLS version to accept: `1.0`, `1.1`, `1.2` or `1.3`. Defaults to `1.2`. " +
+					"This can also be specified with the `COBBLER_TLS_MIN_VERSION` shell environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clientpkg.TLSVersionNames...),
+				},
+			},
+			"headers": schema.MapAttribute{
+				Description: "Additional HTTP headers to send with every request, e.g. an `Authorization` header for a gateway in front of Cobbler. " +
+					"This can also be specified with the `COBBLER_HEADERS` shell environment variable as a JSON object.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"api_path": schema.StringAttribute{
+				Description: "The path the Cobbler XML-RPC API is served at, if it differs from the path in `url`, e.g. `/cobbler_api`. " +
+					"This can also be specified with the `COBBLER_API_PATH` shell environment variable.",
+				Optional: true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description: "The number of seconds a single request to Cobbler may take before it is aborted. Defaults to no timeout. " +
+					"This can also be specified with the `COBBLER_REQUEST_TIMEOUT` shell environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"read_only": schema.BoolAttribute{
+				Description: "If set to true, the provider refuses every operation that would modify Cobbler, including syncs, before contacting the server. " +
+					"Reads, imports and data sources keep working, e.g. for plan-only pipelines. " +
+					"This can also be specified with the `COBBLER_READ_ONLY` shell environment variable.",
+				Optional: true,
+			},
+			"sync_mode": schema.StringAttribute{
+				Description: "When to run `cobbler sync` after a resource is created, updated or deleted. `per_resource` (default) syncs after every change, " +
+					"`coalesced` debounces concurrent changes from all resources into a single shared sync, and `none` never syncs. " +
+					"This can also be specified with the `COBBLER_SYNC_MODE` shell environment variable.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clientpkg.SyncModes...),
+				},
+			},
+			"force_full_sync": schema.BoolAttribute{
+				Description: "If set to true, system and network interface changes run a full `cobbler sync` instead of Cobbler's per-system and DHCP-only syncs. " +
+					"This can also be specified with the `COBBLER_FORCE_FULL_SYNC` shell environment variable.",
+				Optional: true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "How often a request is retried after a transient failure, such as a refused connection while cobblerd restarts. " +
+					"Requests that may already have reached Cobbler are only retried if they are idempotent. Defaults to `3`. " +
+					"This can also be specified with the `COBBLER_MAX_RETRIES` shell environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_max_wait": schema.Int64Attribute{
+				Description: "The maximum number of seconds to wait between two retries. The wait starts at one second and doubles for every retry. Defaults to `30`. " +
+					"This can also be specified with the `COBBLER_RETRY_MAX_WAIT` shell environment variable.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
 
 // providerModel maps to the provider schema attributes.
 type providerModel struct {
-	URL        types.String `tfsdk:"url"`
-	Username   types.String `tfsdk:"username"`
-	Password   types.String `tfsdk:"password"`
-	Insecure   types.Bool   `tfsdk:"insecure"`
-	CACertFile types.String `tfsdk:"cacert_file"`
+	URL             types.String `tfsdk:"url"`
+	Username        types.String `tfsdk:"username"`
+	Password        types.String `tfsdk:"password"`
+	PasswordFile    types.String `tfsdk:"password_file"`
+	PasswordCommand types.String `tfsdk:"password_command"`
+	ConfigFile      types.String `tfsdk:"config_file"`
+	Profile         types.String `tfsdk:"profile"`
+	Insecure        types.Bool   `tfsdk:"insecure"`
+	CACertFile      types.String `tfsdk:"cacert_file"`
+	CACertAppend    types.Bool   `tfsdk:"cacert_append"`
+	ClientCertFile  types.String `tfsdk:"client_cert_file"`
+	ClientKeyFile   types.String `tfsdk:"client_key_file"`
+	TLSServerName   types.String `tfsdk:"tls_server_name"`
+	TLSMinVersion   types.String `tfsdk:"tls_min_version"`
+	Headers         types.Map    `tfsdk:"headers"`
+	APIPath         types.String `tfsdk:"api_path"`
+	RequestTimeout  types.Int64  `tfsdk:"request_timeout"`
+	ReadOnly        types.Bool   `tfsdk:"read_only"`
+	SyncMode        types.String `tfsdk:"sync_mode"`
+	ForceFullSync   types.Bool   `tfsdk:"force_full_sync"`
+	MaxRetries      types.Int64  `tfsdk:"max_retries"`
+	RetryMaxWait    types.Int64  `tfsdk:"retry_max_wait"`
 }
 
 func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
@@ -87,28 +233,192 @@ func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Values from other resources or data sources are unknown until apply. Login is lazy, so
+	// the provider can still be configured; API calls fail until the values are known.
+	var unknown []string
+	for name, value := range map[string]attr.Value{
+		"url":              data.URL,
+		"username":         data.Username,
+		"password":         data.Password,
+		"password_file":    data.PasswordFile,
+		"password_command": data.PasswordCommand,
+		"config_file":      data.ConfigFile,
+		"profile":          data.Profile,
+		"insecure":         data.Insecure,
+		"cacert_file":      data.CACertFile,
+		"cacert_append":    data.CACertAppend,
+		"client_cert_file": data.ClientCertFile,
+		"client_key_file":  data.ClientKeyFile,
+		"tls_server_name":  data.TLSServerName,
+		"tls_min_version":  data.TLSMinVersion,
+		"headers":          data.Headers,
+		"api_path":         data.APIPath,
+		"request_timeout":  data.RequestTimeout,
+		"read_only":        data.ReadOnly,
+		"sync_mode":        data.SyncMode,
+		"force_full_sync":  data.ForceFullSync,
+		"max_retries":      data.MaxRetries,
+		"retry_max_wait":   data.RetryMaxWait,
+	} {
+		if value.IsUnknown() {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	if len(unknown) > 0 && req.ClientCapabilities.DeferralAllowed {
+		resp.Deferred = &provider.Deferred{Reason: provider.DeferredReasonProviderConfigUnknown}
+		return
+	}
+
+	// The config file is the lowest layer: attributes win over environment variables, which
+	// win over the file.
+	configFile := data.ConfigFile.ValueString()
+	if configFile == "" {
+		configFile = os.Getenv("COBBLER_CONFIG_FILE")
+	}
+	profile := data.Profile.ValueString()
+	if profile == "" {
+		profile = os.Getenv("COBBLER_PROFILE")
+	}
+	fileProfile, err := clientpkg.LoadConfigFile(configFile, profile)
+	if err != nil {
+		attribute := path.Root("config_file")
+		if profile != "" {
+			attribute = path.Root("profile")
+		}
+		resp.Diagnostics.AddAttributeError(attribute, "Invalid Cobbler Config File", err.Error())
+		return
+	}
+
 	url := data.URL.ValueString()
 	if url == "" {
 		url = os.Getenv("COBBLER_URL")
 	}
+	if url == "" {
+		url = fileProfile.URL
+	}
 	username := data.Username.ValueString()
 	if username == "" {
 		username = os.Getenv("COBBLER_USERNAME")
 	}
-	password := data.Password.ValueString()
-	if password == "" {
-		password = os.Getenv("COBBLER_PASSWORD")
+	if username == "" {
+		username = fileProfile.Username
+	}
+	var password string
+	passwordKnown := !slices.Contains(unknown, "password") && !slices.Contains(unknown, "password_file") && !slices.Contains(unknown, "password_command")
+	if passwordKnown {
+		var passwordDiags diag.Diagnostics
+		password = resolvePassword(ctx, data, fileProfile, &passwordDiags)
+		resp.Diagnostics.Append(passwordDiags...)
+		passwordKnown = !passwordDiags.HasError()
 	}
 	insecure := data.Insecure.ValueBool()
 	if !insecure && os.Getenv("COBBLER_INSECURE") == "true" {
 		insecure = true
 	}
+	if data.Insecure.IsNull() && os.Getenv("COBBLER_INSECURE") == "" && fileProfile.Insecure != nil {
+		insecure = *fileProfile.Insecure
+	}
 	cacertFile := data.CACertFile.ValueString()
 	if cacertFile == "" {
 		cacertFile = os.Getenv("COBBLER_CACERT_FILE")
 	}
+	if cacertFile == "" {
+		cacertFile = fileProfile.CACertFile
+	}
+	cacertAppend := data.CACertAppend.ValueBool()
+	if !cacertAppend && os.Getenv("COBBLER_CACERT_APPEND") == "true" {
+		cacertAppend = true
+	}
+	clientCertFile := data.ClientCertFile.ValueString()
+	if clientCertFile == "" {
+		clientCertFile = os.Getenv("COBBLER_CLIENT_CERT_FILE")
+	}
+	clientKeyFile := data.ClientKeyFile.ValueString()
+	if clientKeyFile == "" {
+		clientKeyFile = os.Getenv("COBBLER_CLIENT_KEY_FILE")
+	}
+	tlsServerName := data.TLSServerName.ValueString()
+	if tlsServerName == "" {
+		tlsServerName = os.Getenv("COBBLER_TLS_SERVER_NAME")
+	}
+	tlsMinVersion := data.TLSMinVersion.ValueString()
+	if tlsMinVersion == "" {
+		tlsMinVersion = os.Getenv("COBBLER_TLS_MIN_VERSION")
+	}
+	headers := map[string]string{}
+	if !data.Headers.IsNull() && !data.Headers.IsUnknown() {
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+	} else if v := os.Getenv("COBBLER_HEADERS"); v != "" {
+		if err := json.Unmarshal([]byte(v), &headers); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("headers"),
+				"Invalid Cobbler Headers",
+				fmt.Sprintf("The COBBLER_HEADERS environment variable must be a JSON object of header names to values: %s", err),
+			)
+		}
+	}
+	apiPath := data.APIPath.ValueString()
+	if apiPath == "" {
+		apiPath = os.Getenv("COBBLER_API_PATH")
+	}
+	var requestTimeout int64
+	if !data.RequestTimeout.IsNull() {
+		requestTimeout = data.RequestTimeout.ValueInt64()
+	} else if v := os.Getenv("COBBLER_REQUEST_TIMEOUT"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid Cobbler Request Timeout",
+				fmt.Sprintf("The COBBLER_REQUEST_TIMEOUT environment variable must be a positive number of seconds, got %q.", v),
+			)
+		}
+		requestTimeout = n
+	}
+	// An unknown read_only may still turn out to be true, so nothing may be modified yet.
+	readOnly := data.ReadOnly.ValueBool() || data.ReadOnly.IsUnknown()
+	if !readOnly && os.Getenv("COBBLER_READ_ONLY") == "true" {
+		readOnly = true
+	}
+	syncMode := data.SyncMode.ValueString()
+	if syncMode == "" {
+		syncMode = os.Getenv("COBBLER_SYNC_MODE")
+	}
+	forceFullSync := data.ForceFullSync.ValueBool()
+	if !forceFullSync && os.Getenv("COBBLER_FORCE_FULL_SYNC") == "true" {
+		forceFullSync = true
+	}
+	maxRetries := int64(clientpkg.DefaultMaxRetries)
+	if !data.MaxRetries.IsNull() {
+		maxRetries = data.MaxRetries.ValueInt64()
+	} else if v := os.Getenv("COBBLER_MAX_RETRIES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("max_retries"),
+				"Invalid Cobbler Max Retries",
+				fmt.Sprintf("The COBBLER_MAX_RETRIES environment variable must be a non-negative integer, got %q.", v),
+			)
+		}
+		maxRetries = n
+	}
+	retryMaxWait := int64(clientpkg.DefaultRetryMaxWait / time.Second)
+	if !data.RetryMaxWait.IsNull() {
+		retryMaxWait = data.RetryMaxWait.ValueInt64()
+	} else if v := os.Getenv("COBBLER_RETRY_MAX_WAIT"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("retry_max_wait"),
+				"Invalid Cobbler Retry Max Wait",
+				fmt.Sprintf("The COBBLER_RETRY_MAX_WAIT environment variable must be a positive number of seconds, got %q.", v),
+			)
+		}
+		retryMaxWait = n
+	}
 
-	if url == "" {
+	if url == "" && !slices.Contains(unknown, "url") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("url"),
 			"Missing Cobbler URL",
@@ -116,7 +426,7 @@ func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureR
 				"Set the url value in the configuration or use the COBBLER_URL environment variable.",
 		)
 	}
-	if username == "" {
+	if username == "" && !slices.Contains(unknown, "username") {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Missing Cobbler Username",
@@ -124,12 +434,36 @@ func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureR
 				"Set the username value in the configuration or use the COBBLER_USERNAME environment variable.",
 		)
 	}
-	if password == "" {
+	if password == "" && passwordKnown {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing Cobbler Password",
 			"The provider cannot create the Cobbler client because there is a missing or empty value for the Cobbler password. "+
-				"Set the password value in the configuration or use the COBBLER_PASSWORD environment variable.",
+				"Set the password, password_file or password_command value in the configuration, use the COBBLER_PASSWORD, "+
+				"COBBLER_PASSWORD_FILE or COBBLER_PASSWORD_COMMAND environment variables, or add it to the config file.",
+		)
+	}
+	if (clientCertFile == "") != (clientKeyFile == "") && !slices.Contains(unknown, "client_cert_file") && !slices.Contains(unknown, "client_key_file") {
+		resp.Diagnostics.AddError(
+			"Incomplete Cobbler Client Certificate",
+			"Both client_cert_file and client_key_file must be set to authenticate with a client certificate. "+
+				"Set both values in the configuration or use the COBBLER_CLIENT_CERT_FILE and COBBLER_CLIENT_KEY_FILE environment variables.",
+		)
+	}
+	if _, ok := clientpkg.TLSVersions[tlsMinVersion]; tlsMinVersion != "" && !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_min_version"),
+			"Invalid Cobbler TLS Minimum Version",
+			fmt.Sprintf("The TLS version %q is not supported. Use one of %s in the configuration or the COBBLER_TLS_MIN_VERSION environment variable.",
+				tlsMinVersion, strings.Join(clientpkg.TLSVersionNames, ", ")),
+		)
+	}
+	if syncMode != "" && !slices.Contains(clientpkg.SyncModes, syncMode) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sync_mode"),
+			"Invalid Cobbler Sync Mode",
+			fmt.Sprintf("The sync mode %q is not supported. Use one of %s in the configuration or the COBBLER_SYNC_MODE environment variable.",
+				syncMode, strings.Join(clientpkg.SyncModes, ", ")),
 		)
 	}
 	if resp.Diagnostics.HasError() {
@@ -137,11 +471,25 @@ func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureR
 	}
 
 	cfg := &clientpkg.Config{
-		URL:        url,
-		Username:   username,
-		Password:   password,
-		Insecure:   insecure,
-		CACertFile: cacertFile,
+		URL:            url,
+		Username:       username,
+		Password:       password,
+		Insecure:       insecure,
+		CACertFile:     cacertFile,
+		CACertAppend:   cacertAppend,
+		ClientCertFile: clientCertFile,
+		ClientKeyFile:  clientKeyFile,
+		TLSServerName:  tlsServerName,
+		TLSMinVersion:  tlsMinVersion,
+		Headers:        headers,
+		APIPath:        apiPath,
+		RequestTimeout: time.Duration(requestTimeout) * time.Second,
+		ReadOnly:       readOnly,
+		SyncMode:       syncMode,
+		ForceFullSync:  forceFullSync,
+		MaxRetries:     int(maxRetries),
+		RetryMaxWait:   time.Duration(retryMaxWait) * time.Second,
+		Unknown:        unknown,
 	}
 
 	if err := cfg.LoadAndValidate(util.Read); err != nil {
@@ -153,9 +501,53 @@ func (p *CobblerProvider) Configure(ctx context.Context, req provider.ConfigureR
 	resp.DataSourceData = cfg
 }
 
+// resolvePassword returns the first password found in the provider configuration, the
+// environment and finally the config file. Within each layer an inline password wins over
+// a password file, which wins over a password command.
+func resolvePassword(ctx context.Context, data providerModel, file clientpkg.FileProfile, diags *diag.Diagnostics) string {
+	layers := []struct {
+		password, passwordFile, passwordCommand string
+		filePath, commandPath                   path.Path
+	}{
+		{
+			data.Password.ValueString(), data.PasswordFile.ValueString(), data.PasswordCommand.ValueString(),
+			path.Root("password_file"), path.Root("password_command"),
+		},
+		{
+			os.Getenv("COBBLER_PASSWORD"), os.Getenv("COBBLER_PASSWORD_FILE"), os.Getenv("COBBLER_PASSWORD_COMMAND"),
+			path.Root("password_file"), path.Root("password_command"),
+		},
+		{
+			file.Password, file.PasswordFile, file.PasswordCommand,
+			path.Root("config_file"), path.Root("config_file"),
+		},
+	}
+	for _, layer := range layers {
+		switch {
+		case layer.password != "":
+			return layer.password
+		case layer.passwordFile != "":
+			password, err := clientpkg.ReadPasswordFile(layer.passwordFile)
+			if err != nil {
+				diags.AddAttributeError(layer.filePath, "Invalid Cobbler Password File", err.Error())
+			}
+			return password
+		case layer.passwordCommand != "":
+			password, err := clientpkg.RunPasswordCommand(ctx, layer.passwordCommand)
+			if err != nil {
+				diags.AddAttributeError(layer.commandPath, "Invalid Cobbler Password Command", err.Error())
+			}
+			return password
+		}
+	}
+	return ""
+}
+
 func (p *CobblerProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		buildiso.NewResource,
 		distro.NewResource,
+		distro_import.NewResource,
 		distro_group.NewResource,
 		image.NewResource,
 		menu.NewResource,
@@ -163,14 +555,20 @@ func (p *CobblerProvider) Resources(_ context.Context) []func() resource.Resourc
 		profile.NewResource,
 		profile_group.NewResource,
 		repo.NewResource,
+		reposync.NewResource,
+		setting.NewResource,
 		system.NewResource,
 		system_group.NewResource,
+		system_netboot.NewResource,
+		system_power.NewResource,
 		template.NewResource,
 	}
 }
 
 func (p *CobblerProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
+		blended.NewDataSource,
+		boot_config.NewDataSource,
 		distro.NewDataSource,
 		distro_group.NewDataSource,
 		image.NewDataSource,
@@ -178,7 +576,9 @@ func (p *CobblerProvider) DataSources(_ context.Context) []func() datasource.Dat
 		network_interface.NewDataSource,
 		profile.NewDataSource,
 		profile_group.NewDataSource,
+		rendered_autoinstall.NewDataSource,
 		repo.NewDataSource,
+		setting.NewDataSource,
 		system.NewDataSource,
 		system_group.NewDataSource,
 		template.NewDataSource,
@@ -0,0 +1,111 @@
+package rendered_autoinstall
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &RenderedAutoinstallDataSource{}
+
+type RenderedAutoinstallDataSource struct {
+	client cobbler.Client
+}
+
+func NewDataSource() datasource.DataSource {
+	return &RenderedAutoinstallDataSource{}
+}
+
+func (d *RenderedAutoinstallDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_rendered_autoinstall"
+}
+
+func (d *RenderedAutoinstallDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to render the autoinstall file (kickstart, preseed, autoyast, ...) of a Cobbler profile or system, " +
+			"as a machine fetches it from `/cblr/svc/op/autoinstall`.",
+		Attributes: map[string]schema.Attribute{
+			"profile": schema.StringAttribute{
+				Description: "The name of the profile to render the autoinstall file for. Exactly one of `profile` and `system` must be set.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.ExactlyOneOf(path.MatchRoot("profile"), path.MatchRoot("system")),
+				},
+			},
+			"system": schema.StringAttribute{
+				Description: "The name of the system to render the autoinstall file for. Exactly one of `profile` and `system` must be set.",
+				Optional:    true,
+			},
+			"content": schema.StringAttribute{
+				Description: "The rendered autoinstall file.",
+				Computed:    true,
+			},
+			"sha256": schema.StringAttribute{
+				Description: "The hex-encoded SHA-256 checksum of `content`.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *RenderedAutoinstallDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	d.client = cfg.CobblerClient
+}
+
+func (d *RenderedAutoinstallDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data renderedAutoinstallDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	what, name := "profile", data.Profile.ValueString()
+	if !data.System.IsNull() {
+		what, name = "system", data.System.ValueString()
+	}
+
+	// Cobbler renders a system's file when system is set and the profile's otherwise.
+	result, err := d.client.Call("generate_autoinstall", data.Profile.ValueString(), data.System.ValueString())
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, fmt.Sprintf("Error rendering autoinstall file of Cobbler %s %q", what, name), err)
+		return
+	}
+	content, ok := result.(string)
+	if !ok {
+		resp.Diagnostics.AddError("Error rendering autoinstall file",
+			fmt.Sprintf("Unexpected generate_autoinstall result of type %T.", result))
+		return
+	}
+	// Unknown items are reported in the file itself rather than as a fault.
+	if strings.TrimSpace(content) == "# "+what+" not found" {
+		resp.Diagnostics.AddAttributeError(path.Root(what), "Unknown "+what,
+			fmt.Sprintf("Cobbler has no %s named %q.", what, name))
+		return
+	}
+
+	sum := sha256.Sum256([]byte(content))
+	data.Content = types.StringValue(content)
+	data.SHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,10 @@
+package rendered_autoinstall
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type renderedAutoinstallDataSourceModel struct {
+	Profile types.String `tfsdk:"profile"`
+	System  types.String `tfsdk:"system"`
+	Content types.String `tfsdk:"content"`
+	SHA256  types.String `tfsdk:"sha256"`
+}
@@ -0,0 +1,76 @@
+package rendered_autoinstall_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccRenderedAutoinstallDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRenderedAutoinstallDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.cobbler_rendered_autoinstall.profile", "content"),
+					resource.TestMatchResourceAttr("data.cobbler_rendered_autoinstall.profile", "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					resource.TestCheckResourceAttrSet("data.cobbler_rendered_autoinstall.system", "content"),
+					resource.TestMatchResourceAttr("data.cobbler_rendered_autoinstall.system", "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccRenderedAutoinstallDataSource_notFound(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccRenderedAutoinstallDataSourceNotFound,
+				ExpectError: regexp.MustCompile(`Unknown profile`),
+			},
+		},
+	})
+}
+
+const testAccRenderedAutoinstallDataSourceBasic = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-data-source-rendered-autoinstall"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+}
+
+resource "cobbler_profile" "foo" {
+  name        = "foo-data-source-rendered-autoinstall"
+  distro      = cobbler_distro.foo.uid
+  autoinstall = "built-in-sample.seed"
+}
+
+resource "cobbler_system" "foo" {
+  name    = "foo-data-source-rendered-autoinstall"
+  profile = cobbler_profile.foo.uid
+}
+
+data "cobbler_rendered_autoinstall" "profile" {
+  profile = cobbler_profile.foo.name
+}
+
+data "cobbler_rendered_autoinstall" "system" {
+  system = cobbler_system.foo.name
+}
+`
+
+const testAccRenderedAutoinstallDataSourceNotFound = `
+data "cobbler_rendered_autoinstall" "missing" {
+  profile = "foo-data-source-rendered-autoinstall-missing"
+}
+`
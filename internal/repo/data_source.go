@@ -87,6 +87,9 @@ func (d *RepoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.StringAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -100,6 +103,10 @@ func (d *RepoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"proxy": schema.SingleNestedAttribute{
@@ -112,6 +119,9 @@ func (d *RepoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, r
 					"inherited": schema.BoolAttribute{
 						Computed: true,
 					},
+					"effective": schema.StringAttribute{
+						Computed: true,
+					},
 				},
 			},
 		},
@@ -140,7 +150,12 @@ func (d *RepoDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 
 	repo, err := d.client.GetRepo(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Repo", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Repo", err)
+		return
+	}
+	resolved, err := d.client.GetRepo(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Repo", err)
 		return
 	}
 
@@ -151,9 +166,9 @@ func (d *RepoDataSource) Read(ctx context.Context, req datasource.ReadRequest, r
 	data.KeepUpdated = types.BoolValue(repo.KeepUpdated)
 	data.Mirror = types.StringValue(repo.Mirror)
 	data.MirrorLocally = types.BoolValue(repo.MirrorLocally)
-	data.CreateRepoFlags = inherit.StringFrom(ctx, repo.CreateRepoFlags, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, repo.Owners, &resp.Diagnostics)
-	data.Proxy = inherit.StringFrom(ctx, repo.Proxy, &resp.Diagnostics)
+	data.CreateRepoFlags = inherit.StringFrom(ctx, repo.CreateRepoFlags, resolved.CreateRepoFlags, &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, repo.Owners, resolved.Owners, &resp.Diagnostics)
+	data.Proxy = inherit.StringFrom(ctx, repo.Proxy, resolved.Proxy, &resp.Diagnostics)
 
 	env, d2 := types.MapValueFrom(ctx, types.StringType, repo.Environment)
 	resp.Diagnostics.Append(d2...)
@@ -2,7 +2,6 @@ package repo
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -14,9 +13,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -24,8 +23,13 @@ import (
 var _ resource.Resource = &RepoResource{}
 var _ resource.ResourceWithImportState = &RepoResource{}
 
+// repoAttributePaths maps Cobbler fields to schema paths for validation errors.
+var repoAttributePaths = clientpkg.AttributePathsFromModel(repoResourceModel{})
+
 type RepoResource struct {
 	client cobbler.Client
+	syncer *clientpkg.Syncer
+	writes *clientpkg.WriteGuard
 }
 
 func NewResource() resource.Resource {
@@ -132,7 +136,10 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.StringAttribute{
@@ -146,6 +153,9 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.StringAttribute{
+						Computed: true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -153,7 +163,10 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -168,6 +181,10 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"proxy": schema.SingleNestedAttribute{
@@ -175,7 +192,10 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.StringAttribute{
@@ -189,6 +209,9 @@ func (r *RepoResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.StringAttribute{
+						Computed: true,
+					},
 				},
 			},
 		},
@@ -206,9 +229,15 @@ func (r *RepoResource) Configure(_ context.Context, req resource.ConfigureReques
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
 }
 
 func (r *RepoResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data repoResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -224,16 +253,29 @@ func (r *RepoResource) Create(ctx context.Context, req resource.CreateRequest, r
 
 	newRepo, err := r.client.CreateRepo(repo)
 	if err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error creating Cobbler Repo", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Repo", err, repoAttributePaths)
 		return
 	}
 
-	repoToModel(ctx, *newRepo, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetRepo(newRepo.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Repo", err)
+		return
+	}
+	repoToModel(ctx, *newRepo, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *RepoResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -245,15 +287,20 @@ func (r *RepoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	repo, err := r.client.GetRepo(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Repo", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Repo", err, repoAttributePaths)
 		return
 	}
 
-	repoToModel(ctx, *repo, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetRepo(repo.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Repo", err)
+		return
+	}
+	repoToModel(ctx, *repo, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -262,6 +309,10 @@ func (r *RepoResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RepoResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data repoResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -276,17 +327,27 @@ func (r *RepoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 	tflog.Debug(ctx, "Cobbler Repo: Update", map[string]interface{}{"name": repo.Name})
 
 	if err := r.client.UpdateRepo(&repo); err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error updating Cobbler Repo", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Repo", err, repoAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updatedRepo, err := r.client.GetRepo(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Repo after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Repo after update", err, repoAttributePaths)
 		return
 	}
 
-	repoToModel(ctx, *updatedRepo, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetRepo(updatedRepo.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler Repo", err)
+		return
+	}
+	repoToModel(ctx, *updatedRepo, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -295,6 +356,10 @@ func (r *RepoResource) Update(ctx context.Context, req resource.UpdateRequest, r
 }
 
 func (r *RepoResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data repoResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -304,7 +369,12 @@ func (r *RepoResource) Delete(ctx context.Context, req resource.DeleteRequest, r
 	tflog.Debug(ctx, "Cobbler Repo: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteRepo(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Repo", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Repo", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -356,7 +426,7 @@ func modelToRepo(ctx context.Context, data repoResourceModel, diags *diag.Diagno
 }
 
 // repoToModel populates a repoResourceModel from a cobbler.Repo.
-func repoToModel(ctx context.Context, repo cobbler.Repo, data *repoResourceModel, diags *diag.Diagnostics) {
+func repoToModel(ctx context.Context, repo cobbler.Repo, resolved cobbler.Repo, data *repoResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(repo.Name)
 	data.Arch = types.StringValue(repo.Arch)
 	data.Breed = types.StringValue(repo.Breed)
@@ -364,9 +434,9 @@ func repoToModel(ctx context.Context, repo cobbler.Repo, data *repoResourceModel
 	data.KeepUpdated = types.BoolValue(repo.KeepUpdated)
 	data.Mirror = types.StringValue(repo.Mirror)
 	data.MirrorLocally = types.BoolValue(repo.MirrorLocally)
-	data.CreateRepoFlags = inherit.StringFrom(ctx, repo.CreateRepoFlags, diags)
-	data.Owners = inherit.StringListFrom(ctx, repo.Owners, diags)
-	data.Proxy = inherit.StringFrom(ctx, repo.Proxy, diags)
+	data.CreateRepoFlags = inherit.StringFrom(ctx, repo.CreateRepoFlags, resolved.CreateRepoFlags, diags)
+	data.Owners = inherit.StringListFrom(ctx, repo.Owners, resolved.Owners, diags)
+	data.Proxy = inherit.StringFrom(ctx, repo.Proxy, resolved.Proxy, diags)
 
 	env, d := types.MapValueFrom(ctx, types.StringType, repo.Environment)
 	diags.Append(d...)
@@ -0,0 +1,177 @@
+package reposync
+
+import (
+	"context"
+	"time"
+
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &ReposyncResource{}
+
+type ReposyncResource struct {
+	tasks  *clientpkg.TaskRunner
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &ReposyncResource{}
+}
+
+func (r *ReposyncResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_reposync"
+}
+
+func (r *ReposyncResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_reposync` mirrors Cobbler repos, like `cobbler reposync`. The reposync runs as a Cobbler " +
+			"background task when the resource is created and again whenever `repos`, `tries` or `triggers` change. " +
+			"Destroying the resource leaves the mirrors in place.",
+		Attributes: map[string]schema.Attribute{
+			"repos": schema.ListAttribute{
+				Description: "The names of the repos to mirror, e.g. `[cobbler_repo.foo.name]`. Repos need `mirror_locally = true` to be mirrored.",
+				Required:    true,
+				ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"tries": schema.Int64Attribute{
+				Description: "How often Cobbler tries to mirror each repo before giving up. Defaults to `1`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the reposync again when they change, e.g. a timestamp or a version.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The number of seconds to wait for the reposync task to finish. Defaults to `1800`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(int64(clientpkg.DefaultTaskTimeout / time.Second)),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"task_id": schema.StringAttribute{
+				Description: "The event id of the Cobbler reposync task.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ReposyncResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.tasks = cfg.Tasks
+	r.writes = cfg.Writes
+}
+
+func (r *ReposyncResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data reposyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var repos []string
+	resp.Diagnostics.Append(data.Repos.ElementsAs(ctx, &repos, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler Reposync: Create", map[string]interface{}{"repos": repos})
+
+	options := map[string]interface{}{
+		"repos":  repos,
+		"tries":  data.Tries.ValueInt64(),
+		"nofail": false,
+	}
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
+	taskID, err := r.tasks.Run(ctx, "background_reposync", options, timeout)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error mirroring Cobbler Repos", err)
+		return
+	}
+
+	data.TaskID = types.StringValue(taskID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReposyncResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// A reposync is an action; there is nothing in Cobbler to refresh.
+	var data reposyncResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReposyncResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Only timeout can change in place; it applies to the next reposync.
+	var data reposyncResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ReposyncResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// The mirrors belong to the repos; removing them is up to cobbler_repo.
+}
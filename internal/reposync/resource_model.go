@@ -0,0 +1,11 @@
+package reposync
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type reposyncResourceModel struct {
+	Repos    types.List   `tfsdk:"repos"`
+	Tries    types.Int64  `tfsdk:"tries"`
+	Triggers types.Map    `tfsdk:"triggers"`
+	Timeout  types.Int64  `tfsdk:"timeout"`
+	TaskID   types.String `tfsdk:"task_id"`
+}
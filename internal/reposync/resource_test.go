@@ -0,0 +1,70 @@
+package reposync_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccReposyncResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccReposyncResourceBasic1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_reposync.foo", "repos.0", "foo-resource-reposync-basic"),
+					resource.TestCheckResourceAttr("cobbler_reposync.foo", "tries", "1"),
+					resource.TestCheckResourceAttrSet("cobbler_reposync.foo", "task_id"),
+				),
+			},
+			{
+				Config: testAccReposyncResourceBasic2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_reposync.foo", "triggers.version", "2"),
+					resource.TestCheckResourceAttrSet("cobbler_reposync.foo", "task_id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccReposyncResourceBasic1 = `
+resource "cobbler_repo" "foo" {
+  name           = "foo-resource-reposync-basic"
+  breed          = "apt"
+  arch           = "x86_64"
+  apt_components = ["main"]
+  apt_dists      = ["focal"]
+  mirror         = "http://us.archive.ubuntu.com/ubuntu/"
+  mirror_locally = false
+}
+
+resource "cobbler_reposync" "foo" {
+  repos = [cobbler_repo.foo.name]
+  triggers = {
+    version = "1"
+  }
+}
+`
+
+const testAccReposyncResourceBasic2 = `
+resource "cobbler_repo" "foo" {
+  name           = "foo-resource-reposync-basic"
+  breed          = "apt"
+  arch           = "x86_64"
+  apt_components = ["main"]
+  apt_dists      = ["focal"]
+  mirror         = "http://us.archive.ubuntu.com/ubuntu/"
+  mirror_locally = false
+}
+
+resource "cobbler_reposync" "foo" {
+  repos = [cobbler_repo.foo.name]
+  triggers = {
+    version = "2"
+  }
+}
+`
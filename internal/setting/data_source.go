@@ -0,0 +1,71 @@
+package setting
+
+import (
+	"context"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/cobbler/terraform-provider-cobbler/internal/util"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ datasource.DataSource = &SettingsDataSource{}
+
+type SettingsDataSource struct {
+	client cobbler.Client
+}
+
+func NewDataSource() datasource.DataSource {
+	return &SettingsDataSource{}
+}
+
+func (d *SettingsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_settings"
+}
+
+func (d *SettingsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Use this data source to read the settings of the Cobbler server.",
+		Attributes: map[string]schema.Attribute{
+			"settings": schema.DynamicAttribute{
+				Description: "An object with one attribute per Cobbler setting. Values keep the type Cobbler reports, " +
+					"so booleans, numbers, lists and nested dicts can be used without conversion, e.g. " +
+					"`data.cobbler_settings.this.settings.manage_dhcp`.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *SettingsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Data Source Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	d.client = cfg.CobblerClient
+}
+
+func (d *SettingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data settingsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := getSettings(d.client)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Settings", err)
+		return
+	}
+
+	data.Settings = types.DynamicValue(util.TerraformValue(ctx, settings))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,7 @@
+package setting
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type settingsDataSourceModel struct {
+	Settings types.Dynamic `tfsdk:"settings"`
+}
@@ -0,0 +1,28 @@
+package setting_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSettingsDataSource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingsDataSourceBasic,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.cobbler_settings.all", "settings.server"),
+					resource.TestCheckResourceAttrSet("data.cobbler_settings.all", "settings.manage_dhcp"),
+				),
+			},
+		},
+	})
+}
+
+const testAccSettingsDataSourceBasic = `
+data "cobbler_settings" "all" {}
+`
@@ -0,0 +1,215 @@
+package setting
+
+import (
+	"context"
+	"fmt"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &SettingResource{}
+var _ resource.ResourceWithImportState = &SettingResource{}
+
+type SettingResource struct {
+	client cobbler.Client
+	syncer *clientpkg.Syncer
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &SettingResource{}
+}
+
+func (r *SettingResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_setting"
+}
+
+func (r *SettingResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_setting` manages a single Cobbler setting through the `modify_setting` API. " +
+			"Cobbler only accepts setting changes when `allow_dynamic_settings` is enabled in its settings.yaml. " +
+			"Destroying the resource restores the value the setting had before it was managed. An imported setting " +
+			"has no such value, so destroying it leaves the setting unchanged.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Description: "The name of the setting, e.g. `manage_dhcp`. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"value": schema.StringAttribute{
+				Description: "The value of the setting, converted by Cobbler to the setting's type: `true`/`false` " +
+					"for booleans, space-separated values for lists and space-separated `key=value` pairs for dicts.",
+				Required: true,
+			},
+			"previous_value": schema.StringAttribute{
+				Description: "The value the setting had before this resource managed it. It is restored on destroy. " +
+					"Null for an imported setting, whose value before it was managed is unknown.",
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SettingResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+}
+
+func (r *SettingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data settingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.Name.ValueString()
+	settings, err := getSettings(r.client)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Settings", err)
+		return
+	}
+	current, ok := settings[name]
+	if !ok {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Unknown Cobbler Setting",
+			fmt.Sprintf("Cobbler has no setting named %q.", name))
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler Setting: Create", map[string]interface{}{"name": name})
+
+	if err := modifySetting(r.client, name, data.Value.ValueString()); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error modifying Cobbler Setting", err)
+		return
+	}
+
+	data.PreviousValue = types.StringValue(formatSetting(current))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the setting is tracked in state, so a failed sync does not lose previous_value.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
+}
+
+func (r *SettingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data settingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, err := getSettings(r.client)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Settings", err)
+		return
+	}
+	current, ok := settings[data.Name.ValueString()]
+	if !ok {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Keep the configured spelling as long as Cobbler holds the same value, so that e.g.
+	// "yes" for a boolean setting does not show up as a change to "true".
+	if data.Value.IsNull() || !sameSetting(data.Value.ValueString(), current) {
+		data.Value = types.StringValue(formatSetting(current))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SettingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data settingResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// previous_value stays null for imported settings, which UseStateForUnknown leaves unknown.
+	if data.PreviousValue.IsUnknown() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("previous_value"), &data.PreviousValue)...)
+	}
+
+	tflog.Debug(ctx, "Cobbler Setting: Update", map[string]interface{}{"name": data.Name.ValueString()})
+
+	if err := modifySetting(r.client, data.Name.ValueString(), data.Value.ValueString()); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error modifying Cobbler Setting", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SettingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data settingResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler Setting: Delete", map[string]interface{}{"name": data.Name.ValueString()})
+
+	if data.PreviousValue.IsNull() {
+		resp.Diagnostics.AddWarning("Cobbler Setting Not Restored",
+			fmt.Sprintf("The setting %q was imported, so the value it had before it was managed is unknown. "+
+				"It keeps its current value; reset it in Cobbler's settings.yaml if needed.", data.Name.ValueString()))
+		return
+	}
+
+	if err := modifySetting(r.client, data.Name.ValueString(), data.PreviousValue.ValueString()); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error restoring Cobbler Setting", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
+}
+
+func (r *SettingResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+}
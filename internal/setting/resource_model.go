@@ -0,0 +1,9 @@
+package setting
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type settingResourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	Value         types.String `tfsdk:"value"`
+	PreviousValue types.String `tfsdk:"previous_value"`
+}
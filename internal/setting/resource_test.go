@@ -0,0 +1,54 @@
+package setting_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccSettingResource_basic(t *testing.T) {
+	acctest.SkipUnlessDynamicSettings(t)
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSettingResourceBasic1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_setting.foo", "name", "default_virt_ram"),
+					resource.TestCheckResourceAttr("cobbler_setting.foo", "value", "1024"),
+					resource.TestCheckResourceAttrSet("cobbler_setting.foo", "previous_value"),
+				),
+			},
+			{
+				Config: testAccSettingResourceBasic2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_setting.foo", "value", "2048"),
+				),
+			},
+			{
+				ResourceName:                         "cobbler_setting.foo",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateId:                        "default_virt_ram",
+				ImportStateVerifyIdentifierAttribute: "name",
+				ImportStateVerifyIgnore:              []string{"previous_value"},
+			},
+		},
+	})
+}
+
+const testAccSettingResourceBasic1 = `
+resource "cobbler_setting" "foo" {
+  name  = "default_virt_ram"
+  value = "1024"
+}
+`
+
+const testAccSettingResourceBasic2 = `
+resource "cobbler_setting" "foo" {
+  name  = "default_virt_ram"
+  value = "2048"
+}
+`
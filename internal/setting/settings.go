@@ -0,0 +1,117 @@
+package setting
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	cobbler "github.com/cobbler/cobblerclient"
+)
+
+// getSettings returns Cobbler's settings as decoded from XML-RPC: bools, int64s, float64s,
+// strings, []interface{} and nested map[string]interface{} values.
+func getSettings(client cobbler.Client) (map[string]interface{}, error) {
+	result, err := client.Call("get_settings", client.Token)
+	if err != nil {
+		return nil, err
+	}
+	settings, ok := result.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected get_settings result of type %T", result)
+	}
+	return settings, nil
+}
+
+// modifySetting sets one setting through modify_setting, which Cobbler only allows when
+// allow_dynamic_settings is enabled.
+func modifySetting(client cobbler.Client, name, value string) error {
+	result, err := client.Call("modify_setting", name, value, client.Token)
+	if err != nil {
+		return err
+	}
+	if code, ok := result.(int64); ok && code != 0 {
+		return fmt.Errorf("cobbler refused to change setting %q; modify_setting requires allow_dynamic_settings to be enabled in Cobbler's settings.yaml", name)
+	}
+	return nil
+}
+
+// formatSetting renders a decoded setting in the string form modify_setting accepts: lists
+// as space-separated values and dicts as space-separated key=value pairs.
+func formatSetting(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = formatSetting(e)
+		}
+		return strings.Join(parts, " ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + "=" + formatSetting(v[k])
+		}
+		return strings.Join(parts, " ")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// sameSetting reports whether Cobbler coerces value to the decoded setting current, using
+// the conversions modify_setting applies for the setting's type.
+func sameSetting(value string, current interface{}) bool {
+	value = strings.TrimSpace(value)
+	switch current := current.(type) {
+	case bool:
+		switch strings.ToLower(value) {
+		case "true", "1", "on", "yes", "y":
+			return current
+		case "false", "0", "off", "no", "n", "":
+			return !current
+		}
+		return false
+	case int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		return err == nil && n == current
+	case float64:
+		f, err := strconv.ParseFloat(value, 64)
+		return err == nil && f == current
+	case []interface{}:
+		fields := strings.Fields(value)
+		if len(fields) != len(current) {
+			return false
+		}
+		for i, e := range current {
+			if fields[i] != formatSetting(e) {
+				return false
+			}
+		}
+		return true
+	case map[string]interface{}:
+		fields := strings.Fields(value)
+		if len(fields) != len(current) {
+			return false
+		}
+		for _, field := range fields {
+			k, v, _ := strings.Cut(field, "=")
+			e, ok := current[k]
+			if !ok || v != formatSetting(e) {
+				return false
+			}
+		}
+		return true
+	default:
+		return value == formatSetting(current)
+	}
+}
@@ -0,0 +1,49 @@
+package setting
+
+import "testing"
+
+func TestFormatSetting(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  string
+	}{
+		{true, "true"},
+		{int64(512), "512"},
+		{1.5, "1.5"},
+		{"example.com", "example.com"},
+		{[]interface{}{"a", "b"}, "a b"},
+		{map[string]interface{}{"b": "2", "a": int64(1)}, "a=1 b=2"},
+		{nil, ""},
+	}
+	for _, tt := range tests {
+		if got := formatSetting(tt.value); got != tt.want {
+			t.Errorf("formatSetting(%#v): expected %q, got %q", tt.value, tt.want, got)
+		}
+	}
+}
+
+func TestSameSetting(t *testing.T) {
+	tests := []struct {
+		value   string
+		current interface{}
+		want    bool
+	}{
+		{"yes", true, true},
+		{"0", false, true},
+		{"true", false, false},
+		{" 512", int64(512), true},
+		{"512.0", int64(512), false},
+		{"1.50", 1.5, true},
+		{"a  b", []interface{}{"a", "b"}, true},
+		{"b a", []interface{}{"a", "b"}, false},
+		{"b=2 a=1", map[string]interface{}{"a": int64(1), "b": "2"}, true},
+		{"a=1", map[string]interface{}{"a": int64(1), "b": "2"}, false},
+		{"example.com", "example.com", true},
+		{"example.org", "example.com", false},
+	}
+	for _, tt := range tests {
+		if got := sameSetting(tt.value, tt.current); got != tt.want {
+			t.Errorf("sameSetting(%q, %#v): expected %v, got %v", tt.value, tt.current, got, tt.want)
+		}
+	}
+}
@@ -140,15 +140,18 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				Description: "Automatic installation template metadata, formerly Kickstart metadata.",
 				Computed:    true,
 				Attributes: map[string]dsschema.Attribute{
-					"value": dsschema.MapAttribute{
+					"value": dsschema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": dsschema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"boot_loaders": dsschema.SingleNestedAttribute{
@@ -164,6 +167,11 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"enable_ipxe": dsschema.SingleNestedAttribute{
@@ -178,36 +186,46 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": dsschema.SingleNestedAttribute{
 				Description: "Kernel options for the system.",
 				Computed:    true,
 				Attributes: map[string]dsschema.Attribute{
-					"value": dsschema.MapAttribute{
+					"value": dsschema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": dsschema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options_post": dsschema.SingleNestedAttribute{
 				Description: "Post install kernel options.",
 				Computed:    true,
 				Attributes: map[string]dsschema.Attribute{
-					"value": dsschema.MapAttribute{
+					"value": dsschema.DynamicAttribute{
 						Description: "The value.",
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": dsschema.BoolAttribute{
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"owners": dsschema.SingleNestedAttribute{
@@ -223,6 +241,11 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": dsschema.MapAttribute{
@@ -242,6 +265,10 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_cpus": dsschema.SingleNestedAttribute{
@@ -256,6 +283,10 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_file_size": dsschema.SingleNestedAttribute{
@@ -270,6 +301,10 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.Float64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_ram": dsschema.SingleNestedAttribute{
@@ -284,6 +319,10 @@ func (d *SystemDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						Description: "If true, inherited from parent.",
 						Computed:    true,
 					},
+					"effective": dsschema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 		},
@@ -312,10 +351,16 @@ func (d *SystemDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 
 	systemPtr, err := d.client.GetSystem(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler System", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler System", err)
 		return
 	}
 	s := *systemPtr
+	resolvedPtr, err := d.client.GetSystem(data.Name.ValueString(), false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler System", err)
+		return
+	}
+	resolved := *resolvedPtr
 
 	data.Name = types.StringValue(s.Name)
 	data.UID = types.StringValue(s.Uid)
@@ -354,16 +399,16 @@ func (d *SystemDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	resp.Diagnostics.Append(diag...)
 	data.TemplateFiles = templateFiles
 
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, s.AutoinstallMeta, &resp.Diagnostics)
-	data.BootLoaders = inherit.StringListFrom(ctx, s.BootLoaders, &resp.Diagnostics)
-	data.EnableIPXE = inherit.BoolFrom(ctx, s.EnableIPXE, &resp.Diagnostics)
-	data.KernelOptions = inherit.StringMapFrom(ctx, s.KernelOptions, &resp.Diagnostics)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, s.KernelOptionsPost, &resp.Diagnostics)
-	data.Owners = inherit.StringListFrom(ctx, s.Owners, &resp.Diagnostics)
-	data.VirtAutoBoot = inherit.BoolFrom(ctx, s.Virt.AutoBoot, &resp.Diagnostics)
-	data.VirtCPUs = inherit.IntFrom(ctx, s.Virt.Cpus, &resp.Diagnostics)
-	data.VirtFileSize = inherit.Float64From(ctx, s.Virt.FileSize, &resp.Diagnostics)
-	data.VirtRAM = inherit.IntFrom(ctx, s.Virt.Ram, &resp.Diagnostics)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, s.AutoinstallMeta, resolved.AutoinstallMeta, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.BootLoaders = inherit.StringListFrom(ctx, s.BootLoaders, resolved.BootLoaders, &resp.Diagnostics)
+	data.EnableIPXE = inherit.BoolFrom(ctx, s.EnableIPXE, resolved.EnableIPXE, &resp.Diagnostics)
+	data.KernelOptions = inherit.MapFrom(ctx, s.KernelOptions, resolved.KernelOptions, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, s.KernelOptionsPost, resolved.KernelOptionsPost, types.ObjectNull(inherit.MapAttrTypes), &resp.Diagnostics)
+	data.Owners = inherit.StringListFrom(ctx, s.Owners, resolved.Owners, &resp.Diagnostics)
+	data.VirtAutoBoot = inherit.BoolFrom(ctx, s.Virt.AutoBoot, resolved.Virt.AutoBoot, &resp.Diagnostics)
+	data.VirtCPUs = inherit.IntFrom(ctx, s.Virt.Cpus, resolved.Virt.Cpus, &resp.Diagnostics)
+	data.VirtFileSize = inherit.Float64From(ctx, s.Virt.FileSize, resolved.Virt.FileSize, &resp.Diagnostics)
+	data.VirtRAM = inherit.IntFrom(ctx, s.Virt.Ram, resolved.Virt.Ram, &resp.Diagnostics)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
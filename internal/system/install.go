@@ -0,0 +1,185 @@
+package system
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultInstallTimeout is how long wait_for_install waits unless timeout says otherwise.
+	defaultInstallTimeout = time.Hour
+	// defaultInstallPollInterval is the default wait between two install status polls.
+	defaultInstallPollInterval = 30 * time.Second
+)
+
+type waitForInstallModel struct {
+	Timeout      types.Int64 `tfsdk:"timeout"`
+	PollInterval types.Int64 `tfsdk:"poll_interval"`
+}
+
+// installStatus is the installation Cobbler last saw for one IP address, as reported by
+// get_status from the install start/stop events anamon and the autoinstall triggers log.
+type installStatus struct {
+	Start  float64
+	Stop   float64
+	Target string
+	State  string
+}
+
+func (s installStatus) finished() bool {
+	return s.Stop > s.Start
+}
+
+func (s installStatus) stalled() bool {
+	// Cobbler reports installations that started over 100 minutes ago without finishing
+	// as "unknown/stalled".
+	return strings.HasPrefix(s.State, "unknown")
+}
+
+func (s installStatus) String() string {
+	if s.Start == 0 {
+		return "no installation seen"
+	}
+	return fmt.Sprintf("%s, started %s", s.State, time.Unix(int64(s.Start), 0).UTC().Format(time.RFC3339))
+}
+
+// parseInstallStatus decodes a get_status entry. Cobbler 3.3 and later report a struct;
+// older servers a list of [start, stop, target, seen starts, seen stops, state].
+func parseInstallStatus(v interface{}) (installStatus, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		target, _ := v["most_recent_target"].(string)
+		state, _ := v["state"].(string)
+		return installStatus{
+			Start:  number(v["most_recent_start"]),
+			Stop:   number(v["most_recent_stop"]),
+			Target: target,
+			State:  state,
+		}, true
+	case []interface{}:
+		if len(v) < 6 {
+			return installStatus{}, false
+		}
+		target, _ := v[2].(string)
+		state, _ := v[5].(string)
+		return installStatus{Start: number(v[0]), Stop: number(v[1]), Target: target, State: state}, true
+	}
+	return installStatus{}, false
+}
+
+func number(v interface{}) float64 {
+	switch v := v.(type) {
+	case float64:
+		return v
+	case int64:
+		return float64(v)
+	}
+	return 0
+}
+
+// latestInstall returns the most recently started installation of the named system across
+// all IP addresses in a get_status report.
+func latestInstall(report map[string]interface{}, name string) installStatus {
+	var latest installStatus
+	for _, entry := range report {
+		status, ok := parseInstallStatus(entry)
+		if ok && status.Target == "system:"+name && status.Start >= latest.Start {
+			latest = status
+		}
+	}
+	return latest
+}
+
+// installStatusOf returns the latest installation of the named system.
+func (r *SystemResource) installStatusOf(name string) (installStatus, error) {
+	result, err := r.client.Call("get_status", "normal", r.client.Token)
+	if err != nil {
+		return installStatus{}, err
+	}
+	report, _ := result.(map[string]interface{})
+	return latestInstall(report, name), nil
+}
+
+// waitForInstall polls Cobbler until an installation of the named system that started after
+// baseline has finished and Cobbler has turned netboot_enabled off again. An installation
+// that stalls or does not finish in time is reported as an error, so resources depending on
+// the system do not run against a machine that is not installed.
+func (r *SystemResource) waitForInstall(ctx context.Context, name string, baseline installStatus, obj types.Object, diags *diag.Diagnostics) {
+	var wait waitForInstallModel
+	diags.Append(obj.As(ctx, &wait, basetypes.ObjectAsOptions{})...)
+	if diags.HasError() {
+		return
+	}
+	timeout := time.Duration(wait.Timeout.ValueInt64()) * time.Second
+	if timeout <= 0 {
+		timeout = defaultInstallTimeout
+	}
+	interval := time.Duration(wait.PollInterval.ValueInt64()) * time.Second
+	if interval <= 0 {
+		interval = defaultInstallPollInterval
+	}
+
+	tflog.Info(ctx, "Cobbler System: waiting for installation", map[string]interface{}{"name": name, "timeout": timeout.String()})
+
+	deadline := time.Now().Add(timeout)
+	current := baseline
+	for {
+		status, err := r.installStatusOf(name)
+		if err != nil {
+			clientpkg.AddClientError(diags, "Error reading Cobbler install status", err)
+			return
+		}
+		if status.Start > baseline.Start {
+			current = status
+			if current.stalled() {
+				diags.AddAttributeError(path.Root("wait_for_install"), "Installation stalled",
+					fmt.Sprintf("The installation of system %q did not report completion (%s). "+
+						"Check the console of the machine and Cobbler's install log.", name, current))
+				return
+			}
+			if current.finished() {
+				system, err := r.client.GetSystem(name, false, false)
+				if err != nil {
+					clientpkg.AddClientError(diags, "Error reading Cobbler System", err)
+					return
+				}
+				if !system.NetbootEnabled {
+					tflog.Info(ctx, "Cobbler System: installation finished", map[string]interface{}{"name": name})
+					return
+				}
+			}
+		}
+		tflog.Debug(ctx, "Cobbler System: installation in progress", map[string]interface{}{"name": name, "status": current.String()})
+
+		if !time.Now().Before(deadline) {
+			detail := fmt.Sprintf("The installation of system %q did not finish within %s (%s).", name, timeout, current)
+			if current.Start == baseline.Start {
+				detail = fmt.Sprintf("No installation of system %q started within %s. Check that the machine boots from the network, "+
+					"e.g. with power_on_create or reboot_on_netboot_enable.", name, timeout)
+			} else if current.finished() {
+				detail = fmt.Sprintf("The installation of system %q finished, but Cobbler did not turn netboot_enabled off within %s. "+
+					"Check that pxe_just_once is enabled in Cobbler's settings.", name, timeout)
+			}
+			diags.AddAttributeError(path.Root("wait_for_install"), "Installation did not finish", detail)
+			return
+		}
+
+		timer := time.NewTimer(min(interval, time.Until(deadline)))
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			diags.AddError("Installation did not finish", ctx.Err().Error())
+			return
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package system
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestLatestInstall(t *testing.T) {
+	report := map[string]interface{}{
+		"10.0.0.1": map[string]interface{}{
+			"most_recent_start":  1700000000.0,
+			"most_recent_stop":   1700000900.0,
+			"most_recent_target": "system:web01",
+			"state":              "finished",
+		},
+		"10.0.0.2": map[string]interface{}{
+			"most_recent_start":  1700005000.0,
+			"most_recent_stop":   0.0,
+			"most_recent_target": "system:web01",
+			"state":              "installing (3m 12s)",
+		},
+		"10.0.0.3": []interface{}{1700009000.0, 1700009500.0, "system:db01", int64(1), int64(1), "finished"},
+	}
+
+	web := latestInstall(report, "web01")
+	if web.Start != 1700005000 || web.finished() || web.stalled() {
+		t.Errorf("expected the running installation, got %+v", web)
+	}
+	db := latestInstall(report, "db01")
+	if db.Start != 1700009000 || !db.finished() {
+		t.Errorf("expected the finished installation from the list format, got %+v", db)
+	}
+	if none := latestInstall(report, "mail01"); none.Start != 0 || none.String() != "no installation seen" {
+		t.Errorf("expected no installation, got %+v", none)
+	}
+}
+
+func TestInstallStatus_stalled(t *testing.T) {
+	status, ok := parseInstallStatus(map[string]interface{}{
+		"most_recent_start":  1700000000.0,
+		"most_recent_target": "system:web01",
+		"state":              "unknown/stalled",
+	})
+	if !ok || !status.stalled() {
+		t.Errorf("expected a stalled installation, got %+v", status)
+	}
+	if _, ok := parseInstallStatus([]interface{}{1.0, 2.0}); ok {
+		t.Error("expected a short list to be rejected")
+	}
+}
+
+func TestKeepsNetbootEnabled(t *testing.T) {
+	wait := types.ObjectValueMust(
+		map[string]attr.Type{"timeout": types.Int64Type, "poll_interval": types.Int64Type},
+		map[string]attr.Value{"timeout": types.Int64Value(3600), "poll_interval": types.Int64Value(30)},
+	)
+	waitNull := types.ObjectNull(map[string]attr.Type{"timeout": types.Int64Type, "poll_interval": types.Int64Type})
+	tests := []struct {
+		name   string
+		ignore types.Bool
+		wait   types.Object
+		want   bool
+	}{
+		{"default", types.BoolValue(false), waitNull, false},
+		{"imported", types.BoolNull(), waitNull, false},
+		{"ignore_netboot_enabled", types.BoolValue(true), waitNull, true},
+		{"wait_for_install", types.BoolValue(false), wait, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := systemResourceModel{IgnoreNetbootEnabled: tt.ignore, WaitForInstall: tt.wait}
+			if got := keepsNetbootEnabled(data); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
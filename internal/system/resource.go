@@ -2,32 +2,52 @@ package system
 
 import (
 	"context"
-	"strings"
+	"fmt"
+	"time"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
 	"github.com/cobbler/terraform-provider-cobbler/internal/inherit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ resource.Resource = &SystemResource{}
 var _ resource.ResourceWithImportState = &SystemResource{}
+var _ resource.ResourceWithModifyPlan = &SystemResource{}
+var _ resource.ResourceWithUpgradeState = &SystemResource{}
+
+// systemAttributePaths maps Cobbler fields to schema paths for validation errors.
+var systemAttributePaths = clientpkg.AttributePathsFromModel(systemResourceModel{})
+
+// powerTimeout is how long power_on_create and reboot_on_netboot_enable wait for Cobbler's
+// power management.
+const powerTimeout = 5 * time.Minute
 
 type SystemResource struct {
-	client cobbler.Client
+	client  cobbler.Client
+	syncer  *clientpkg.Syncer
+	tasks   *clientpkg.TaskRunner
+	writes  *clientpkg.WriteGuard
+	changes *clientpkg.PlannedChanges
 }
 
+// systemParents are the attributes referencing the items a system inherits from.
+var systemParents = []path.Path{path.Root("profile"), path.Root("image")}
+
 func NewResource() resource.Resource {
 	return &SystemResource{}
 }
@@ -39,6 +59,7 @@ func (r *SystemResource) Metadata(_ context.Context, req resource.MetadataReques
 func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "`cobbler_system` manages a system within Cobbler.",
+		Version:     1,
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
 				Description: "The name of the system.",
@@ -86,6 +107,13 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"ignore_netboot_enabled": schema.BoolAttribute{
+				Description: "Leave `netboot_enabled` to Cobbler and other resources such as `cobbler_system_netboot`: updates keep the value on the server, " +
+					"and a server-side change, e.g. Cobbler turning it off after an installation, is not reported as drift. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 			"image": schema.StringAttribute{
 				Description: "The Cobbler UID of the parent image (if no profile is used). Use `cobbler_image.foo.uid`.",
 				Optional:    true,
@@ -169,6 +197,12 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"power_on_create": schema.BoolAttribute{
+				Description: "Power the machine on through Cobbler's power management after the system was created, e.g. to start the installation right away. Requires `power_type` and `power_address`. A failed power action fails the apply. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"power_type": schema.StringAttribute{
 				Description: "Power management type.",
 				Optional:    true,
@@ -185,6 +219,12 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"reboot_on_netboot_enable": schema.BoolAttribute{
+				Description: "Reboot the machine through Cobbler's power management whenever an update turns `netboot_enabled` on, so that it reinstalls in the same apply. A failed power action fails the apply. Defaults to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
 			"profile": schema.StringAttribute{
 				Description: "The Cobbler UID of the parent profile. Use `cobbler_profile.foo.uid`.",
 				Required:    true,
@@ -250,14 +290,16 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -267,6 +309,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"boot_loaders": schema.SingleNestedAttribute{
@@ -274,7 +320,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -291,6 +340,11 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"enable_ipxe": schema.SingleNestedAttribute{
@@ -298,7 +352,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.BoolAttribute{
@@ -314,6 +371,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options": schema.SingleNestedAttribute{
@@ -321,14 +382,16 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -338,6 +401,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"kernel_options_post": schema.SingleNestedAttribute{
@@ -345,14 +412,16 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
-					"value": schema.MapAttribute{
+					"value": schema.DynamicAttribute{
 						Description: "The value.",
 						Optional:    true,
 						Computed:    true,
-						ElementType: types.StringType,
 					},
 					"inherited": schema.BoolAttribute{
 						Description: "If true, inherited from parent.",
@@ -362,6 +431,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.DynamicAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"owners": schema.SingleNestedAttribute{
@@ -369,7 +442,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.ListAttribute{
@@ -386,6 +462,11 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.ListAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						ElementType: types.StringType,
+						Computed:    true,
+					},
 				},
 			},
 			"template_files": schema.MapAttribute{
@@ -402,7 +483,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.BoolAttribute{
@@ -418,6 +502,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.BoolAttribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_cpus": schema.SingleNestedAttribute{
@@ -425,7 +513,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Int64Attribute{
@@ -441,6 +532,37 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
+				},
+			},
+			"wait_for_install": schema.SingleNestedAttribute{
+				Description: "Wait for the operating system installation whenever an apply turns `netboot_enabled` on, until the machine reports the " +
+					"installation finished and Cobbler turned `netboot_enabled` off again (requires `pxe_just_once` in Cobbler's settings). " +
+					"The machine has to boot from the network on its own or through `power_on_create` or `reboot_on_netboot_enable`. " +
+					"Cobbler turning `netboot_enabled` off is not drift. An installation that stalls or does not finish in time fails the apply.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.Int64Attribute{
+						Description: "The number of seconds to wait for the installation to finish. Defaults to `3600`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(int64(defaultInstallTimeout / time.Second)),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"poll_interval": schema.Int64Attribute{
+						Description: "The number of seconds between two checks of the installation status. Defaults to `30`.",
+						Optional:    true,
+						Computed:    true,
+						Default:     int64default.StaticInt64(int64(defaultInstallPollInterval / time.Second)),
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
 				},
 			},
 			"virt_file_size": schema.SingleNestedAttribute{
@@ -448,7 +570,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Float64Attribute{
@@ -464,6 +589,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Float64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 			"virt_ram": schema.SingleNestedAttribute{
@@ -471,7 +600,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Optional:    true,
 				Computed:    true,
 				PlanModifiers: []planmodifier.Object{
-					objectplanmodifier.UseStateForUnknown(),
+					inherit.UseStateForUnknown(systemParents...),
+				},
+				Validators: []validator.Object{
+					inherit.Validator(),
 				},
 				Attributes: map[string]schema.Attribute{
 					"value": schema.Int64Attribute{
@@ -487,6 +619,10 @@ func (r *SystemResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 							boolplanmodifier.UseStateForUnknown(),
 						},
 					},
+					"effective": schema.Int64Attribute{
+						Description: "The value in effect after inheritance is resolved.",
+						Computed:    true,
+					},
 				},
 			},
 		},
@@ -504,9 +640,28 @@ func (r *SystemResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.tasks = cfg.Tasks
+	r.writes = cfg.Writes
+	r.changes = cfg.Changes
+}
+
+func (r *SystemResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	inherit.ModifyPlan(ctx, r.changes, req, resp, systemParents...)
+}
+
+// UpgradeState upgrades state written before the map values became dynamic.
+func (r *SystemResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: inherit.UpgradeMapState("autoinstall_meta", "kernel_options", "kernel_options_post"),
+	}
 }
 
 func (r *SystemResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data systemResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -522,29 +677,41 @@ func (r *SystemResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	newSystem, err := r.client.CreateSystem(system)
 	if err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error creating Cobbler System", err)
-		return
-	}
-
-	tflog.Debug(ctx, "Cobbler System: syncing system")
-	if err := r.client.Sync(); err != nil {
-		resp.Diagnostics.AddError("Error syncing Cobbler", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler System", err, systemAttributePaths)
 		return
 	}
 
 	// Read back the system to get computed values
 	readSystem, err := r.client.GetSystem(newSystem.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler System after create", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler System after create", err, systemAttributePaths)
 		return
 	}
 
-	systemToModel(ctx, *readSystem, &data, &resp.Diagnostics)
+	resolved, err := r.client.GetSystem(readSystem.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler System", err)
+		return
+	}
+	systemToModel(ctx, *readSystem, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the system is tracked in state, so a failed sync does not orphan it.
+	tflog.Debug(ctx, "Cobbler System: syncing system")
+	if err := r.syncer.SyncSystems(ctx, system.Name); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+		return
+	}
+
+	r.boot(ctx, system.Name, data.PowerOnCreate.ValueBool(), clientpkg.PowerOn,
+		data.NetbootEnabled.ValueBool(), data.WaitForInstall, &resp.Diagnostics)
 }
 
 func (r *SystemResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -556,25 +723,49 @@ func (r *SystemResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	system, err := r.client.GetSystem(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading System", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading System", err, systemAttributePaths)
 		return
 	}
 
-	systemToModel(ctx, *system, &data, &resp.Diagnostics)
+	netbootEnabled := data.NetbootEnabled
+	resolved, err := r.client.GetSystem(system.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler System", err)
+		return
+	}
+	systemToModel(ctx, *system, *resolved, &data, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if keepsNetbootEnabled(data) {
+		data.NetbootEnabled = netbootEnabled
+	}
+	// Imported systems start out with the defaults of the Terraform-only attributes.
+	if data.IgnoreNetbootEnabled.IsNull() {
+		data.IgnoreNetbootEnabled = types.BoolValue(false)
+	}
+	if data.PowerOnCreate.IsNull() {
+		data.PowerOnCreate = types.BoolValue(false)
+	}
+	if data.RebootOnNetbootEnable.IsNull() {
+		data.RebootOnNetbootEnable = types.BoolValue(false)
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *SystemResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var plan systemResourceModel
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state systemResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -584,35 +775,65 @@ func (r *SystemResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	// wait_for_install only sends netboot_enabled when the configuration changes it.
+	ignoreNetboot := plan.IgnoreNetbootEnabled.ValueBool() ||
+		(!plan.WaitForInstall.IsNull() && plan.NetbootEnabled.Equal(state.NetbootEnabled))
+	if ignoreNetboot {
+		// UpdateSystem writes every field; keep whatever netboot_enabled the server has.
+		current, err := r.client.GetSystem(newSystem.Name, false, false)
+		if err != nil {
+			clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler System before update", err, systemAttributePaths)
+			return
+		}
+		newSystem.NetbootEnabled = current.NetbootEnabled
+	}
+
 	tflog.Debug(ctx, "Cobbler System: Update", map[string]interface{}{"name": newSystem.Name})
 
 	if err := r.client.UpdateSystem(&newSystem); err != nil {
-		clientpkg.AddClientError(&resp.Diagnostics, "Error updating Cobbler System", err)
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler System", err, systemAttributePaths)
 		return
 	}
 
 	tflog.Debug(ctx, "Cobbler System: syncing system")
-	if err := r.client.Sync(); err != nil {
-		resp.Diagnostics.AddError("Error syncing Cobbler", err.Error())
+	if err := r.syncer.SyncSystems(ctx, newSystem.Name); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	// Read back updated system
 	readSystem, err := r.client.GetSystem(plan.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler System after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler System after update", err, systemAttributePaths)
 		return
 	}
 
-	systemToModel(ctx, *readSystem, &plan, &resp.Diagnostics)
+	plannedNetboot := plan.NetbootEnabled
+	resolved, err := r.client.GetSystem(readSystem.Name, false, true)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading resolved Cobbler System", err)
+		return
+	}
+	systemToModel(ctx, *readSystem, *resolved, &plan, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	if ignoreNetboot {
+		plan.NetbootEnabled = plannedNetboot
+	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+
+	netbootEnabled := !ignoreNetboot && newSystem.NetbootEnabled && !state.NetbootEnabled.ValueBool()
+	r.boot(ctx, newSystem.Name, netbootEnabled && plan.RebootOnNetbootEnable.ValueBool(), clientpkg.PowerReboot,
+		netbootEnabled, plan.WaitForInstall, &resp.Diagnostics)
 }
 
 func (r *SystemResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data systemResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -622,7 +843,13 @@ func (r *SystemResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	tflog.Debug(ctx, "Cobbler System: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteSystem(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler System", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler System", err)
+		return
+	}
+
+	// Cobbler removes the system's own boot files on delete; only DHCP needs regenerating.
+	if err := r.syncer.SyncDHCP(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
@@ -630,6 +857,41 @@ func (r *SystemResource) ImportState(ctx context.Context, req resource.ImportSta
 	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
 }
 
+// boot runs after the system's changes were saved. It runs the power action if power is set
+// and, if the apply turned netboot on and wait_for_install is configured, waits for the
+// installation. Failures are errors, so Terraform reports that the machine did not
+// (re)install; the system is saved in state already.
+func (r *SystemResource) boot(ctx context.Context, name string, power bool, action string, netbootEnabled bool, wait types.Object, diags *diag.Diagnostics) {
+	waitForInstall := netbootEnabled && !wait.IsNull() && !wait.IsUnknown()
+	var baseline installStatus
+	if waitForInstall {
+		var err error
+		if baseline, err = r.installStatusOf(name); err != nil {
+			clientpkg.AddClientError(diags, "Error reading Cobbler install status", err)
+			return
+		}
+	}
+
+	if power {
+		tflog.Debug(ctx, "Cobbler System: power", map[string]interface{}{"name": name, "action": action})
+		if _, err := r.tasks.Power(ctx, action, []string{name}, powerTimeout); err != nil {
+			clientpkg.AddClientError(diags, fmt.Sprintf("Error running power action %q for Cobbler System", action), err)
+			return
+		}
+	}
+
+	if waitForInstall {
+		r.waitForInstall(ctx, name, baseline, wait, diags)
+	}
+}
+
+// keepsNetbootEnabled reports whether a server-side change of netboot_enabled is not drift:
+// with ignore_netboot_enabled, and with wait_for_install, which expects Cobbler to turn it off
+// after every installation.
+func keepsNetbootEnabled(data systemResourceModel) bool {
+	return data.IgnoreNetbootEnabled.ValueBool() || !data.WaitForInstall.IsNull()
+}
+
 // systemStringOrInherit returns "<<inherit>>" when s is null, unknown, or empty.
 // Cobbler rejects empty strings for enum-validated fields (e.g. virt_disk_driver, virt_type).
 func systemStringOrInherit(s types.String) string {
@@ -684,11 +946,11 @@ func modelToSystem(ctx context.Context, data systemResourceModel, diags *diag.Di
 	}
 	system.TemplateFiles = templateFiles
 
-	system.AutoinstallMeta = inherit.StringMapTo(ctx, data.AutoinstallMeta, diags)
+	system.AutoinstallMeta = inherit.MapTo(ctx, data.AutoinstallMeta, diags)
 	system.BootLoaders = inherit.StringListTo(ctx, data.BootLoaders, diags)
 	system.EnableIPXE = inherit.BoolTo(ctx, data.EnableIPXE, diags)
-	system.KernelOptions = inherit.StringMapTo(ctx, data.KernelOptions, diags)
-	system.KernelOptionsPost = inherit.StringMapTo(ctx, data.KernelOptionsPost, diags)
+	system.KernelOptions = inherit.MapTo(ctx, data.KernelOptions, diags)
+	system.KernelOptionsPost = inherit.MapTo(ctx, data.KernelOptionsPost, diags)
 	system.Owners = inherit.StringListTo(ctx, data.Owners, diags)
 	system.Virt.AutoBoot = inherit.BoolTo(ctx, data.VirtAutoBoot, diags)
 	system.Virt.Cpus = inherit.IntTo(ctx, data.VirtCPUs, diags)
@@ -699,7 +961,7 @@ func modelToSystem(ctx context.Context, data systemResourceModel, diags *diag.Di
 }
 
 // systemToModel populates a systemResourceModel from a cobbler.System.
-func systemToModel(ctx context.Context, system cobbler.System, data *systemResourceModel, diags *diag.Diagnostics) {
+func systemToModel(ctx context.Context, system cobbler.System, resolved cobbler.System, data *systemResourceModel, diags *diag.Diagnostics) {
 	data.Name = types.StringValue(system.Name)
 	data.UID = types.StringValue(system.Uid)
 	data.Autoinstall = types.StringValue(system.Autoinstall)
@@ -737,14 +999,14 @@ func systemToModel(ctx context.Context, system cobbler.System, data *systemResou
 	diags.Append(d...)
 	data.TemplateFiles = templateFiles
 
-	data.AutoinstallMeta = inherit.StringMapFrom(ctx, system.AutoinstallMeta, diags)
-	data.BootLoaders = inherit.StringListFrom(ctx, system.BootLoaders, diags)
-	data.EnableIPXE = inherit.BoolFrom(ctx, system.EnableIPXE, diags)
-	data.KernelOptions = inherit.StringMapFrom(ctx, system.KernelOptions, diags)
-	data.KernelOptionsPost = inherit.StringMapFrom(ctx, system.KernelOptionsPost, diags)
-	data.Owners = inherit.StringListFrom(ctx, system.Owners, diags)
-	data.VirtAutoBoot = inherit.BoolFrom(ctx, system.Virt.AutoBoot, diags)
-	data.VirtCPUs = inherit.IntFrom(ctx, system.Virt.Cpus, diags)
-	data.VirtFileSize = inherit.Float64From(ctx, system.Virt.FileSize, diags)
-	data.VirtRAM = inherit.IntFrom(ctx, system.Virt.Ram, diags)
+	data.AutoinstallMeta = inherit.MapFrom(ctx, system.AutoinstallMeta, resolved.AutoinstallMeta, data.AutoinstallMeta, diags)
+	data.BootLoaders = inherit.StringListFrom(ctx, system.BootLoaders, resolved.BootLoaders, diags)
+	data.EnableIPXE = inherit.BoolFrom(ctx, system.EnableIPXE, resolved.EnableIPXE, diags)
+	data.KernelOptions = inherit.MapFrom(ctx, system.KernelOptions, resolved.KernelOptions, data.KernelOptions, diags)
+	data.KernelOptionsPost = inherit.MapFrom(ctx, system.KernelOptionsPost, resolved.KernelOptionsPost, data.KernelOptionsPost, diags)
+	data.Owners = inherit.StringListFrom(ctx, system.Owners, resolved.Owners, diags)
+	data.VirtAutoBoot = inherit.BoolFrom(ctx, system.Virt.AutoBoot, resolved.Virt.AutoBoot, diags)
+	data.VirtCPUs = inherit.IntFrom(ctx, system.Virt.Cpus, resolved.Virt.Cpus, diags)
+	data.VirtFileSize = inherit.Float64From(ctx, system.Virt.FileSize, resolved.Virt.FileSize, diags)
+	data.VirtRAM = inherit.IntFrom(ctx, system.Virt.Ram, resolved.Virt.Ram, diags)
 }
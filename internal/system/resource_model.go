@@ -29,6 +29,11 @@ type systemResourceModel struct {
 	VirtPXEBoot       types.Bool   `tfsdk:"virt_pxe_boot"`
 	VirtType          types.String `tfsdk:"virt_type"`
 	VirtUEFI          types.Bool   `tfsdk:"virt_uefi"`
+	// Terraform-only:
+	IgnoreNetbootEnabled  types.Bool   `tfsdk:"ignore_netboot_enabled"`
+	PowerOnCreate         types.Bool   `tfsdk:"power_on_create"`
+	RebootOnNetbootEnable types.Bool   `tfsdk:"reboot_on_netboot_enable"`
+	WaitForInstall        types.Object `tfsdk:"wait_for_install"`
 	// Inheritable:
 	AutoinstallMeta   types.Object `tfsdk:"autoinstall_meta"`
 	BootLoaders       types.Object `tfsdk:"boot_loaders"`
@@ -18,6 +18,9 @@ func TestAccSystemResource_basic(t *testing.T) {
 					resource.TestCheckResourceAttr("cobbler_system.foo", "name", "foo-resource-system-basic"),
 					resource.TestCheckResourceAttrPair("cobbler_system.foo", "profile", "cobbler_profile.foo", "uid"),
 					resource.TestCheckResourceAttr("cobbler_system.foo", "comment", "I'm a system"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "power_on_create", "false"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "reboot_on_netboot_enable", "false"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "ignore_netboot_enabled", "false"),
 				),
 			},
 			{
@@ -59,6 +62,31 @@ func TestAccSystemResource_change(t *testing.T) {
 	})
 }
 
+// TestAccSystemResource_typedMaps checks that flag-only kernel options and non-string
+// autoinstall_meta entries survive a round trip without a diff.
+func TestAccSystemResource_typedMaps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemResourceTypedMaps,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_system.foo", "kernel_options.value.console", "ttyS0"),
+					resource.TestCheckNoResourceAttr("cobbler_system.foo", "kernel_options.value.quiet"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "autoinstall_meta.value.disks", "2"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "autoinstall_meta.value.raid", "true"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "autoinstall_meta.value.packages.1", "vim"),
+				),
+			},
+			{
+				Config:   testAccSystemResourceTypedMaps,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
 // testAccSystemDistroProfile is the shared distro+profile config used by system tests.
 const testAccSystemDistroProfile = `
 resource "cobbler_distro" "foo" {
@@ -105,3 +133,19 @@ resource "cobbler_system" "foo" {
   power_id     = "foo"
 }
 `
+
+const testAccSystemResourceTypedMaps = testAccSystemDistroProfile + `
+resource "cobbler_system" "foo" {
+  name    = "foo-resource-system-typed-maps"
+  profile = cobbler_profile.foo.uid
+
+  kernel_options = {
+    inherited = false
+    value     = { console = "ttyS0", quiet = null }
+  }
+  autoinstall_meta = {
+    inherited = false
+    value     = { disks = 2, raid = true, packages = ["git", "vim"] }
+  }
+}
+`
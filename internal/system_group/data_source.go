@@ -57,7 +57,7 @@ func (d *SystemGroupDataSource) Read(ctx context.Context, req datasource.ReadReq
 
 	g, err := d.client.GetSystemGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler SystemGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler SystemGroup", err)
 		return
 	}
 
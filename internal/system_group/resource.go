@@ -2,7 +2,6 @@ package system_group
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -20,8 +19,12 @@ import (
 var _ resource.Resource = &SystemGroupResource{}
 var _ resource.ResourceWithImportState = &SystemGroupResource{}
 
+// systemGroupAttributePaths maps Cobbler fields to schema paths for validation errors.
+var systemGroupAttributePaths = clientpkg.AttributePathsFromModel(systemGroupResourceModel{})
+
 type SystemGroupResource struct {
 	client cobbler.Client
+	writes *clientpkg.WriteGuard
 }
 
 func NewResource() resource.Resource {
@@ -75,6 +78,7 @@ func (r *SystemGroupResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.writes = cfg.Writes
 }
 
 func modelToGroup(ctx context.Context, data systemGroupResourceModel, diags *diag.Diagnostics) cobbler.SystemGroup {
@@ -120,6 +124,10 @@ func groupToDataSourceModel(ctx context.Context, g cobbler.SystemGroup, data *sy
 }
 
 func (r *SystemGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data systemGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -135,7 +143,7 @@ func (r *SystemGroupResource) Create(ctx context.Context, req resource.CreateReq
 
 	created, err := r.client.CreateSystemGroup(g)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler SystemGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler SystemGroup", err, systemGroupAttributePaths)
 		return
 	}
 
@@ -155,11 +163,11 @@ func (r *SystemGroupResource) Read(ctx context.Context, req resource.ReadRequest
 
 	g, err := r.client.GetSystemGroup(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler SystemGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler SystemGroup", err, systemGroupAttributePaths)
 		return
 	}
 
@@ -171,6 +179,10 @@ func (r *SystemGroupResource) Read(ctx context.Context, req resource.ReadRequest
 }
 
 func (r *SystemGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data systemGroupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -185,13 +197,13 @@ func (r *SystemGroupResource) Update(ctx context.Context, req resource.UpdateReq
 	tflog.Debug(ctx, "Cobbler SystemGroup: Update", map[string]interface{}{"name": g.Name})
 
 	if err := r.client.UpdateSystemGroup(&g); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler SystemGroup", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler SystemGroup", err, systemGroupAttributePaths)
 		return
 	}
 
 	updated, err := r.client.GetSystemGroup(g.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler SystemGroup after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler SystemGroup after update", err, systemGroupAttributePaths)
 		return
 	}
 
@@ -203,6 +215,10 @@ func (r *SystemGroupResource) Update(ctx context.Context, req resource.UpdateReq
 }
 
 func (r *SystemGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data systemGroupResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -212,7 +228,7 @@ func (r *SystemGroupResource) Delete(ctx context.Context, req resource.DeleteReq
 	tflog.Debug(ctx, "Cobbler SystemGroup: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteSystemGroup(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler SystemGroup", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler SystemGroup", err)
 	}
 }
 
@@ -0,0 +1,198 @@
+package system_netboot
+
+import (
+	"context"
+	"fmt"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &SystemNetbootResource{}
+var _ resource.ResourceWithImportState = &SystemNetbootResource{}
+
+type SystemNetbootResource struct {
+	client cobbler.Client
+	syncer *clientpkg.Syncer
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &SystemNetbootResource{}
+}
+
+func (r *SystemNetbootResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_netboot"
+}
+
+func (r *SystemNetbootResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_system_netboot` turns `netboot_enabled` on for a Cobbler system, so that it (re)installs at its next network boot. " +
+			"Netboot is enabled when the resource is created and again whenever `reinstall_trigger` changes. Cobbler turning `netboot_enabled` off " +
+			"after the installation is not drift. Import the resource to adopt an installed system without reinstalling it. " +
+			"Set `ignore_netboot_enabled` on the `cobbler_system` so that it leaves the attribute alone. Destroying the resource does not change the system.",
+		Attributes: map[string]schema.Attribute{
+			"system": schema.StringAttribute{
+				Description: "The UID of the system, e.g. `cobbler_system.foo.uid`. Changing this forces a new resource.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"reinstall_trigger": schema.StringAttribute{
+				Description: "An arbitrary value that enables netboot again when it changes, e.g. a counter or a date.",
+				Optional:    true,
+			},
+			"netboot_enabled": schema.BoolAttribute{
+				Description: "Whether netboot is currently enabled on the Cobbler server.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (r *SystemNetbootResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
+}
+
+func (r *SystemNetbootResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data systemNetbootResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.enable(ctx, &data, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SystemNetbootResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data systemNetbootResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := clientpkg.ItemName(r.client, "system", data.System.ValueString())
+	if clientpkg.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error finding Cobbler System", err)
+		return
+	}
+	system, err := r.client.GetSystem(name, false, false)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler System", err)
+		return
+	}
+
+	// netboot_enabled is only reported; reinstall_trigger alone decides when to enable it again.
+	data.NetbootEnabled = types.BoolValue(system.NetbootEnabled)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SystemNetbootResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var plan, state systemNetbootResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// reinstall_trigger is the only attribute that changes in place.
+	if plan.ReinstallTrigger.Equal(state.ReinstallTrigger) {
+		plan.NetbootEnabled = state.NetbootEnabled
+	} else {
+		r.enable(ctx, &plan, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *SystemNetbootResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Leave netboot_enabled as it is; a pending installation still happens.
+}
+
+func (r *SystemNetbootResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("system"), req, resp)
+}
+
+// enable turns netboot_enabled on for the system in data and regenerates its boot files.
+// Only netboot_enabled is modified, so the rest of the system stays with cobbler_system.
+func (r *SystemNetbootResource) enable(ctx context.Context, data *systemNetbootResourceModel, diags *diag.Diagnostics) {
+	name, err := clientpkg.ItemName(r.client, "system", data.System.ValueString())
+	if clientpkg.IsNotFound(err) {
+		diags.AddAttributeError(path.Root("system"), "Unknown UID",
+			fmt.Sprintf("Cobbler has no system with UID %q.", data.System.ValueString()))
+		return
+	}
+	if err != nil {
+		clientpkg.AddClientError(diags, "Error finding Cobbler System", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler System Netboot: enable", map[string]interface{}{"name": name})
+
+	handle, err := r.client.Call("get_system_handle", name, r.client.Token)
+	if err != nil {
+		clientpkg.AddClientError(diags, "Error enabling netboot for Cobbler System", err)
+		return
+	}
+	if _, err := r.client.Call("modify_system", handle, "netboot_enabled", true, r.client.Token); err != nil {
+		clientpkg.AddClientError(diags, "Error enabling netboot for Cobbler System", err)
+		return
+	}
+	if _, err := r.client.Call("save_system", handle, r.client.Token); err != nil {
+		clientpkg.AddClientError(diags, "Error enabling netboot for Cobbler System", err)
+		return
+	}
+
+	tflog.Debug(ctx, "Cobbler System Netboot: syncing system")
+	if err := r.syncer.SyncSystems(ctx, name); err != nil {
+		clientpkg.AddClientError(diags, "Error syncing Cobbler", err)
+		return
+	}
+
+	data.NetbootEnabled = types.BoolValue(true)
+}
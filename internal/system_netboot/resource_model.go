@@ -0,0 +1,9 @@
+package system_netboot
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type systemNetbootResourceModel struct {
+	System           types.String `tfsdk:"system"`
+	ReinstallTrigger types.String `tfsdk:"reinstall_trigger"`
+	NetbootEnabled   types.Bool   `tfsdk:"netboot_enabled"`
+}
@@ -0,0 +1,82 @@
+package system_netboot_test
+
+import (
+	"testing"
+
+	"github.com/cobbler/terraform-provider-cobbler/internal/acctest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccSystemNetbootResource_basic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { acctest.PreCheck(t); acctest.SkipIfCobblerVersionLessThan(t, 3, 3, 5) },
+		ProtoV6ProviderFactories: acctest.ProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccSystemNetbootResource1,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("cobbler_system_netboot.foo", "system", "cobbler_system.foo", "uid"),
+					resource.TestCheckResourceAttr("cobbler_system_netboot.foo", "reinstall_trigger", "1"),
+					resource.TestCheckResourceAttr("cobbler_system_netboot.foo", "netboot_enabled", "true"),
+				),
+			},
+			{
+				Config: testAccSystemNetbootResource2,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("cobbler_system_netboot.foo", "reinstall_trigger", "2"),
+					resource.TestCheckResourceAttr("cobbler_system_netboot.foo", "netboot_enabled", "true"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "ignore_netboot_enabled", "true"),
+					resource.TestCheckResourceAttr("cobbler_system.foo", "netboot_enabled", "false"),
+				),
+			},
+			{
+				ResourceName:                         "cobbler_system_netboot.foo",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "system",
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return s.RootModule().Resources["cobbler_system.foo"].Primary.Attributes["uid"], nil
+				},
+				ImportStateVerifyIgnore: []string{"reinstall_trigger"},
+			},
+		},
+	})
+}
+
+const testAccSystemNetbootDistroProfile = `
+resource "cobbler_distro" "foo" {
+  name       = "foo-resource-system-netboot"
+  breed      = "ubuntu"
+  os_version = "focal"
+  arch       = "x86_64"
+  kernel     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/vmlinuz"
+  initrd     = "/srv/www/cobbler/distro_mirror/Ubuntu-20.04/install/initrd.gz"
+}
+
+resource "cobbler_profile" "foo" {
+  name   = "foo-resource-system-netboot"
+  distro = cobbler_distro.foo.uid
+}
+
+resource "cobbler_system" "foo" {
+  name                   = "foo-resource-system-netboot"
+  profile                = cobbler_profile.foo.uid
+  netboot_enabled        = false
+  ignore_netboot_enabled = true
+}
+`
+
+const testAccSystemNetbootResource1 = testAccSystemNetbootDistroProfile + `
+resource "cobbler_system_netboot" "foo" {
+  system            = cobbler_system.foo.uid
+  reinstall_trigger = "1"
+}
+`
+
+const testAccSystemNetbootResource2 = testAccSystemNetbootDistroProfile + `
+resource "cobbler_system_netboot" "foo" {
+  system            = cobbler_system.foo.uid
+  reinstall_trigger = "2"
+}
+`
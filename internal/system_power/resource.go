@@ -0,0 +1,188 @@
+package system_power
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var _ resource.Resource = &SystemPowerResource{}
+
+// defaultTimeout is how long a power action may take unless timeout says otherwise.
+const defaultTimeout = 5 * time.Minute
+
+type SystemPowerResource struct {
+	client cobbler.Client
+	tasks  *clientpkg.TaskRunner
+	writes *clientpkg.WriteGuard
+}
+
+func NewResource() resource.Resource {
+	return &SystemPowerResource{}
+}
+
+func (r *SystemPowerResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_system_power"
+}
+
+func (r *SystemPowerResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "`cobbler_system_power` runs a power action for a Cobbler system through its configured power management " +
+			"(`power_type`, `power_address`, ...), like `cobbler system poweron`. The action runs when the resource is created and again " +
+			"whenever `system`, `action` or `triggers` change. Destroying the resource does not change the power state.",
+		Attributes: map[string]schema.Attribute{
+			"system": schema.StringAttribute{
+				Description: "The UID of the system, e.g. `cobbler_system.foo.uid`.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"action": schema.StringAttribute{
+				Description: "The power action: `on`, `off` or `reboot`.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(clientpkg.PowerActions...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that run the power action again when they change, e.g. the profile of the system.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"timeout": schema.Int64Attribute{
+				Description: "The number of seconds to wait for the power action to finish. Defaults to `300`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(int64(defaultTimeout / time.Second)),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"task_id": schema.StringAttribute{
+				Description: "The event id of the Cobbler power task.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *SystemPowerResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	cfg, ok := req.ProviderData.(*clientpkg.Config)
+	if !ok {
+		resp.Diagnostics.AddError("Unexpected Resource Configure Type",
+			"Expected *client.Config, got unexpected type.")
+		return
+	}
+	r.client = cfg.CobblerClient
+	r.tasks = cfg.Tasks
+	r.writes = cfg.Writes
+}
+
+func (r *SystemPowerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	var data systemPowerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name, err := clientpkg.ItemName(r.client, "system", data.System.ValueString())
+	if clientpkg.IsNotFound(err) {
+		resp.Diagnostics.AddAttributeError(path.Root("system"), "Unknown UID",
+			fmt.Sprintf("Cobbler has no system with UID %q.", data.System.ValueString()))
+		return
+	}
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error finding Cobbler System", err)
+		return
+	}
+
+	action := data.Action.ValueString()
+	tflog.Debug(ctx, "Cobbler System Power: Create", map[string]interface{}{"name": name, "action": action})
+
+	timeout := time.Duration(data.Timeout.ValueInt64()) * time.Second
+	taskID, err := r.tasks.Power(ctx, action, []string{name}, timeout)
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, fmt.Sprintf("Error running power action %q for Cobbler System", action), err)
+		return
+	}
+
+	data.TaskID = types.StringValue(taskID)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SystemPowerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data systemPowerResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The action is done once it ran; only a deleted system makes it obsolete.
+	_, err := clientpkg.ItemName(r.client, "system", data.System.ValueString())
+	if clientpkg.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error finding Cobbler System", err)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SystemPowerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Only timeout can change in place; it applies to the next power action.
+	var data systemPowerResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SystemPowerResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
+	// Leave the machine in whatever power state it is in.
+}
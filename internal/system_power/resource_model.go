@@ -0,0 +1,11 @@
+package system_power
+
+import "github.com/hashicorp/terraform-plugin-framework/types"
+
+type systemPowerResourceModel struct {
+	System   types.String `tfsdk:"system"`
+	Action   types.String `tfsdk:"action"`
+	Triggers types.Map    `tfsdk:"triggers"`
+	Timeout  types.Int64  `tfsdk:"timeout"`
+	TaskID   types.String `tfsdk:"task_id"`
+}
@@ -0,0 +1,183 @@
+package system_power
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	cobbler "github.com/cobbler/cobblerclient"
+	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/kolo/xmlrpc"
+)
+
+type methodCall struct {
+	Name   string `xml:"methodName"`
+	Params []struct {
+		Value string `xml:",innerxml"`
+	} `xml:"params>param"`
+}
+
+// fakePowerBackend is an XML-RPC server answering the calls of a power action: it knows
+// one system and reports the task states in order, repeating the last one.
+type fakePowerBackend struct {
+	mu      sync.Mutex
+	states  []string
+	polls   int
+	options map[string]interface{}
+}
+
+func newFakePowerBackend(t *testing.T, states ...string) (*fakePowerBackend, cobbler.Client) {
+	t.Helper()
+	f := &fakePowerBackend{states: states}
+	server := httptest.NewServer(http.HandlerFunc(f.serve))
+	t.Cleanup(server.Close)
+	return f, cobbler.NewClient(server.Client(), cobbler.ClientConfig{URL: server.URL})
+}
+
+func (f *fakePowerBackend) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	var req methodCall
+	if err := xml.Unmarshal(body, &req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	args := make([]interface{}, len(req.Params))
+	for i, p := range req.Params {
+		if err := wrapResponse(p.Value).Unmarshal(&args[i]); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var result interface{}
+	switch req.Name {
+	case "find_system":
+		result = []interface{}{}
+		if criteria, _ := args[0].(map[string]interface{}); criteria["uid"] == "uid-web01" {
+			result = []interface{}{"web01"}
+		}
+	case "background_power_system":
+		f.options, _ = args[0].(map[string]interface{})
+		result = "2026-10-16_120000_power"
+	case "get_task_status":
+		state := f.states[min(f.polls, len(f.states)-1)]
+		f.polls++
+		result = []interface{}{1760616000.0, "Power management (power_system)", state, []interface{}{}}
+	case "get_event_log":
+		result = ""
+	default:
+		http.Error(w, "unknown method "+req.Name, http.StatusBadRequest)
+		return
+	}
+	encoded, err := xmlrpc.EncodeMethodCall("", result)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var resp methodCall
+	if err := xml.Unmarshal(encoded, &resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	_, _ = io.WriteString(w, string(wrapResponse(resp.Params[0].Value)))
+}
+
+func wrapResponse(value string) xmlrpc.Response {
+	return xmlrpc.Response("<?xml version='1.0'?><methodResponse><params><param>" + value + "</param></params></methodResponse>")
+}
+
+// create runs Create for a power resource with the given system UID, action and timeout in
+// seconds and returns the response.
+func create(t *testing.T, client cobbler.Client, uid, action string, timeout int64) *resource.CreateResponse {
+	t.Helper()
+	ctx := context.Background()
+	r := &SystemPowerResource{client: client, tasks: clientpkg.NewTaskRunner(client)}
+	var schemaResp resource.SchemaResponse
+	r.Schema(ctx, resource.SchemaRequest{}, &schemaResp)
+
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	diags := plan.Set(ctx, &systemPowerResourceModel{
+		System:   types.StringValue(uid),
+		Action:   types.StringValue(action),
+		Triggers: types.MapNull(types.StringType),
+		Timeout:  types.Int64Value(timeout),
+		TaskID:   types.StringUnknown(),
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	resp := &resource.CreateResponse{State: tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+	}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	return resp
+}
+
+func TestSystemPowerResource_actions(t *testing.T) {
+	for _, action := range clientpkg.PowerActions {
+		t.Run(action, func(t *testing.T) {
+			backend, client := newFakePowerBackend(t, "complete")
+			resp := create(t, client, "uid-web01", action, 1)
+			if resp.Diagnostics.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", resp.Diagnostics)
+			}
+			if backend.options["power"] != action {
+				t.Errorf("expected power action %q, got %v", action, backend.options["power"])
+			}
+			if systems, _ := backend.options["systems"].([]interface{}); len(systems) != 1 || systems[0] != "web01" {
+				t.Errorf("expected the system name, got %v", backend.options["systems"])
+			}
+
+			var data systemPowerResourceModel
+			resp.Diagnostics.Append(resp.State.Get(context.Background(), &data)...)
+			if data.TaskID.ValueString() != "2026-10-16_120000_power" {
+				t.Errorf("unexpected task_id %v", data.TaskID)
+			}
+		})
+	}
+}
+
+func TestSystemPowerResource_failed(t *testing.T) {
+	_, client := newFakePowerBackend(t, "failed")
+	resp := create(t, client, "uid-web01", clientpkg.PowerOn, 1)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "failed") {
+		t.Fatalf("expected a failed task error, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected no state for a failed power action")
+	}
+}
+
+func TestSystemPowerResource_timeout(t *testing.T) {
+	_, client := newFakePowerBackend(t, "running")
+	resp := create(t, client, "uid-web01", clientpkg.PowerReboot, 1)
+	if !resp.Diagnostics.HasError() || !strings.Contains(resp.Diagnostics[0].Detail(), "did not finish within 1s") {
+		t.Fatalf("expected a timeout error, got %v", resp.Diagnostics)
+	}
+	if !resp.State.Raw.IsNull() {
+		t.Error("expected no state for a timed out power action")
+	}
+}
+
+func TestSystemPowerResource_unknownSystem(t *testing.T) {
+	backend, client := newFakePowerBackend(t, "complete")
+	resp := create(t, client, "uid-missing", clientpkg.PowerOn, 1)
+	if !resp.Diagnostics.HasError() || resp.Diagnostics[0].Summary() != "Unknown UID" {
+		t.Fatalf("expected an unknown UID error, got %v", resp.Diagnostics)
+	}
+	if backend.options != nil {
+		t.Errorf("expected no power action, got %v", backend.options)
+	}
+}
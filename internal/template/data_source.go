@@ -68,7 +68,7 @@ func (d *TemplateDataSource) Read(ctx context.Context, req datasource.ReadReques
 
 	tpl, err := d.client.GetTemplate(data.Name.ValueString(), false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Template", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error reading Cobbler Template", err)
 		return
 	}
 
@@ -2,7 +2,6 @@ package template
 
 import (
 	"context"
-	"strings"
 
 	cobbler "github.com/cobbler/cobblerclient"
 	clientpkg "github.com/cobbler/terraform-provider-cobbler/internal/client"
@@ -26,8 +25,13 @@ import (
 var _ resource.Resource = &TemplateResource{}
 var _ resource.ResourceWithImportState = &TemplateResource{}
 
+// templateAttributePaths maps Cobbler fields to schema paths for validation errors.
+var templateAttributePaths = clientpkg.AttributePathsFromModel(templateResourceModel{})
+
 type TemplateResource struct {
 	client cobbler.Client
+	syncer *clientpkg.Syncer
+	writes *clientpkg.WriteGuard
 }
 
 func NewResource() resource.Resource {
@@ -140,6 +144,8 @@ func (r *TemplateResource) Configure(_ context.Context, req resource.ConfigureRe
 		return
 	}
 	r.client = cfg.CobblerClient
+	r.syncer = cfg.Syncer
+	r.writes = cfg.Writes
 }
 
 func parseTemplateSchema(s string) cobbler.TemplateSchema {
@@ -203,7 +209,7 @@ func modelToTemplate(ctx context.Context, data templateResourceModel, diags *dia
 func templateContent(client cobbler.Client, tpl cobbler.Template, diags *diag.Diagnostics) string {
 	content, err := client.GetTemplateContent(tpl.Uid)
 	if err != nil {
-		diags.AddError("Error reading Cobbler Template content", err.Error())
+		clientpkg.AddClientError(diags, "Error reading Cobbler Template content", err)
 	}
 	return content
 }
@@ -243,6 +249,10 @@ func templateToDataSourceModel(ctx context.Context, client cobbler.Client, tpl c
 }
 
 func (r *TemplateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data templateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -258,7 +268,7 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 
 	created, err := r.client.CreateTemplate(tpl)
 	if err != nil {
-		resp.Diagnostics.AddError("Error creating Cobbler Template", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error creating Cobbler Template", err, templateAttributePaths)
 		return
 	}
 
@@ -268,6 +278,14 @@ func (r *TemplateResource) Create(ctx context.Context, req resource.CreateReques
 	}
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Sync only once the item is tracked in state, so a failed sync does not orphan it.
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
+	}
 }
 
 func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -279,11 +297,11 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 
 	tpl, err := r.client.GetTemplate(data.Name.ValueString(), false, false)
 	if err != nil {
-		if strings.Contains(err.Error(), "not found") {
+		if clientpkg.IsNotFound(err) {
 			resp.State.RemoveResource(ctx)
 			return
 		}
-		resp.Diagnostics.AddError("Error reading Cobbler Template", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Template", err, templateAttributePaths)
 		return
 	}
 
@@ -296,6 +314,10 @@ func (r *TemplateResource) Read(ctx context.Context, req resource.ReadRequest, r
 }
 
 func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data templateResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -310,13 +332,18 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 	tflog.Debug(ctx, "Cobbler Template: Update", map[string]interface{}{"name": tpl.Name})
 
 	if err := r.client.UpdateTemplate(&tpl); err != nil {
-		resp.Diagnostics.AddError("Error updating Cobbler Template", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error updating Cobbler Template", err, templateAttributePaths)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 		return
 	}
 
 	updated, err := r.client.GetTemplate(tpl.Name, false, false)
 	if err != nil {
-		resp.Diagnostics.AddError("Error reading Cobbler Template after update", err.Error())
+		clientpkg.AddItemError(&resp.Diagnostics, "Error reading Cobbler Template after update", err, templateAttributePaths)
 		return
 	}
 
@@ -329,6 +356,10 @@ func (r *TemplateResource) Update(ctx context.Context, req resource.UpdateReques
 }
 
 func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	if !r.writes.Check(&resp.Diagnostics) {
+		return
+	}
+
 	var data templateResourceModel
 	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -338,7 +369,12 @@ func (r *TemplateResource) Delete(ctx context.Context, req resource.DeleteReques
 	tflog.Debug(ctx, "Cobbler Template: Delete", map[string]interface{}{"name": data.Name.ValueString()})
 
 	if err := r.client.DeleteTemplate(data.Name.ValueString()); err != nil {
-		resp.Diagnostics.AddError("Error deleting Cobbler Template", err.Error())
+		clientpkg.AddClientError(&resp.Diagnostics, "Error deleting Cobbler Template", err)
+		return
+	}
+
+	if err := r.syncer.Sync(ctx); err != nil {
+		clientpkg.AddClientError(&resp.Diagnostics, "Error syncing Cobbler", err)
 	}
 }
 
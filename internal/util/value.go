@@ -0,0 +1,228 @@
+package util
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// TerraformValue converts a value decoded from Cobbler's XML-RPC API (bools, int64s, float64s,
+// strings, []interface{} and nested map[string]interface{} values) to a Terraform value of
+// the matching type. Lists become tuples and maps objects, so their elements keep their types.
+// None becomes a dynamic null, the type Terraform gives null in an object expression.
+func TerraformValue(ctx context.Context, v interface{}) attr.Value {
+	switch v := v.(type) {
+	case bool:
+		return types.BoolValue(v)
+	case int64:
+		return types.Int64Value(v)
+	case int:
+		return types.Int64Value(int64(v))
+	case float64:
+		return types.Float64Value(v)
+	case string:
+		return types.StringValue(v)
+	case []interface{}:
+		elemTypes := make([]attr.Type, len(v))
+		elems := make([]attr.Value, len(v))
+		for i, e := range v {
+			elems[i] = TerraformValue(ctx, e)
+			elemTypes[i] = elems[i].Type(ctx)
+		}
+		return types.TupleValueMust(elemTypes, elems)
+	case map[string]interface{}:
+		attrTypes := make(map[string]attr.Type, len(v))
+		attrs := make(map[string]attr.Value, len(v))
+		for k, e := range v {
+			attrs[k] = TerraformValue(ctx, e)
+			attrTypes[k] = attrs[k].Type(ctx)
+		}
+		return types.ObjectValueMust(attrTypes, attrs)
+	case nil:
+		return types.DynamicNull()
+	default:
+		return types.StringValue(fmt.Sprint(v))
+	}
+}
+
+// TerraformValueOfType converts a value decoded from Cobbler's XML-RPC API like TerraformValue,
+// but to type t, e.g. the type of the configured value it was sent from, so that a map(string)
+// stays a map instead of becoming an object. Scalars are converted the way Terraform converts
+// them. A value that does not fit t is an error.
+func TerraformValueOfType(ctx context.Context, v interface{}, t attr.Type) (attr.Value, error) {
+	tfType := t.TerraformType(ctx)
+	tfValue, err := terraformValueOfType(ctx, v, tfType)
+	if err != nil {
+		return nil, err
+	}
+	return t.ValueFromTerraform(ctx, tfValue)
+}
+
+func terraformValueOfType(ctx context.Context, v interface{}, t tftypes.Type) (tftypes.Value, error) {
+	if v == nil {
+		return tftypes.NewValue(t, nil), nil
+	}
+	switch {
+	case t.Is(tftypes.DynamicPseudoType):
+		return TerraformValue(ctx, v).ToTerraformValue(ctx)
+	case t.Is(tftypes.String):
+		switch v := v.(type) {
+		case string:
+			return tftypes.NewValue(t, v), nil
+		case bool:
+			return tftypes.NewValue(t, strconv.FormatBool(v)), nil
+		case int64, int, float64:
+			return tftypes.NewValue(t, fmt.Sprint(v)), nil
+		}
+	case t.Is(tftypes.Number):
+		switch v := v.(type) {
+		case int64:
+			return tftypes.NewValue(t, new(big.Float).SetInt64(v)), nil
+		case int:
+			return tftypes.NewValue(t, new(big.Float).SetInt64(int64(v))), nil
+		case float64:
+			return tftypes.NewValue(t, big.NewFloat(v)), nil
+		case string:
+			if f, _, err := big.ParseFloat(v, 10, 512, big.ToNearestEven); err == nil {
+				return tftypes.NewValue(t, f), nil
+			}
+		}
+	case t.Is(tftypes.Bool):
+		switch v := v.(type) {
+		case bool:
+			return tftypes.NewValue(t, v), nil
+		case string:
+			if b, err := strconv.ParseBool(v); err == nil {
+				return tftypes.NewValue(t, b), nil
+			}
+		}
+	case t.Is(tftypes.Map{}):
+		if m, ok := v.(map[string]interface{}); ok {
+			elems := make(map[string]tftypes.Value, len(m))
+			for k, e := range m {
+				elem, err := terraformValueOfType(ctx, e, t.(tftypes.Map).ElementType)
+				if err != nil {
+					return tftypes.Value{}, fmt.Errorf("%s: %w", k, err)
+				}
+				elems[k] = elem
+			}
+			return tftypes.NewValue(t, elems), nil
+		}
+	case t.Is(tftypes.Object{}):
+		attrTypes := t.(tftypes.Object).AttributeTypes
+		if m, ok := v.(map[string]interface{}); ok && len(m) == len(attrTypes) {
+			attrs := make(map[string]tftypes.Value, len(m))
+			for k, e := range m {
+				attrType, ok := attrTypes[k]
+				if !ok {
+					return tftypes.Value{}, fmt.Errorf("unexpected attribute %s", k)
+				}
+				a, err := terraformValueOfType(ctx, e, attrType)
+				if err != nil {
+					return tftypes.Value{}, fmt.Errorf("%s: %w", k, err)
+				}
+				attrs[k] = a
+			}
+			return tftypes.NewValue(t, attrs), nil
+		}
+	case t.Is(tftypes.List{}), t.Is(tftypes.Set{}), t.Is(tftypes.Tuple{}):
+		if l, ok := v.([]interface{}); ok {
+			elems := make([]tftypes.Value, len(l))
+			for i, e := range l {
+				var elemType tftypes.Type
+				switch t := t.(type) {
+				case tftypes.List:
+					elemType = t.ElementType
+				case tftypes.Set:
+					elemType = t.ElementType
+				case tftypes.Tuple:
+					if len(t.ElementTypes) != len(l) {
+						return tftypes.Value{}, fmt.Errorf("expected %d elements, got %d", len(t.ElementTypes), len(l))
+					}
+					elemType = t.ElementTypes[i]
+				}
+				elem, err := terraformValueOfType(ctx, e, elemType)
+				if err != nil {
+					return tftypes.Value{}, fmt.Errorf("[%d]: %w", i, err)
+				}
+				elems[i] = elem
+			}
+			return tftypes.NewValue(t, elems), nil
+		}
+	}
+	return tftypes.Value{}, fmt.Errorf("cannot convert %T to %s", v, t)
+}
+
+// CobblerValue converts a Terraform value back to the form TerraformValue reads, for
+// sending to Cobbler. Whole numbers become int64s, other numbers float64s, lists, sets and
+// tuples []interface{}, and maps and objects map[string]interface{}. Null becomes nil, which
+// Cobbler stores as None. Unknown values are an error.
+func CobblerValue(ctx context.Context, v attr.Value) (interface{}, error) {
+	if v == nil || v.IsNull() {
+		return nil, nil
+	}
+	if v.IsUnknown() {
+		return nil, fmt.Errorf("value is unknown")
+	}
+	switch v := v.(type) {
+	case types.Dynamic:
+		return CobblerValue(ctx, v.UnderlyingValue())
+	case types.String:
+		return v.ValueString(), nil
+	case types.Bool:
+		return v.ValueBool(), nil
+	case types.Int64:
+		return v.ValueInt64(), nil
+	case types.Float64:
+		return v.ValueFloat64(), nil
+	case types.Number:
+		f := v.ValueBigFloat()
+		if f.IsInt() {
+			if i, acc := f.Int64(); acc == big.Exact {
+				return i, nil
+			}
+		}
+		n, _ := f.Float64()
+		return n, nil
+	case types.List:
+		return cobblerValues(ctx, v.Elements())
+	case types.Set:
+		return cobblerValues(ctx, v.Elements())
+	case types.Tuple:
+		return cobblerValues(ctx, v.Elements())
+	case types.Map:
+		return cobblerMap(ctx, v.Elements())
+	case types.Object:
+		return cobblerMap(ctx, v.Attributes())
+	}
+	return nil, fmt.Errorf("unsupported value of type %s", v.Type(ctx))
+}
+
+func cobblerValues(ctx context.Context, elems []attr.Value) ([]interface{}, error) {
+	result := make([]interface{}, len(elems))
+	for i, e := range elems {
+		v, err := CobblerValue(ctx, e)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = v
+	}
+	return result, nil
+}
+
+func cobblerMap(ctx context.Context, elems map[string]attr.Value) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(elems))
+	for k, e := range elems {
+		v, err := CobblerValue(ctx, e)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+		result[k] = v
+	}
+	return result, nil
+}